@@ -7,12 +7,32 @@ import (
 	"time"
 
 	_ "github.com/go-sql-driver/mysql" // MySQL/MariaDB driver
-	_ "github.com/lib/pq"              // PostgreSQL driver
+	"github.com/lib/pq"                // PostgreSQL driver
+
+	"github.com/orchard9/pg-goer/pkg/models"
 )
 
+// MaxOpenConnections is the size of the connection pool, and so the most
+// snapshot transactions that can be open at once.
+const MaxOpenConnections = 25
+
+// snapshotTxOptions starts the read-only REPEATABLE READ transactions that
+// extraction runs in.
+var snapshotTxOptions = &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true}
+
+// queryer is what the analyzers run their queries through: the connection
+// pool, or a snapshot transaction.
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
 type Connection struct {
-	db     *sql.DB
-	dbType DatabaseType
+	db      *sql.DB
+	tx      *sql.Tx // set on connections returned by BeginSnapshot and JoinSnapshot
+	dbType  DatabaseType
+	mariaDB bool // a MySQL-family server that is MariaDB rather than MySQL
 }
 
 func Connect(ctx context.Context, connectionString string) (*Connection, error) {
@@ -31,12 +51,43 @@ func ConnectWithType(ctx context.Context, connectionString string, dbType Databa
 		return nil, fmt.Errorf("failed to parse connection string: %w", err)
 	}
 
+	return open(ctx, driver, connStr, dbType)
+}
+
+// ConnectSafe connects like ConnectWithType, with the safe mode limits set on
+// every session the pool opens. A MySQL-family server is asked its version
+// first, because MariaDB and MySQL name the statement timeout differently.
+func ConnectSafe(ctx context.Context, connectionString string, dbType DatabaseType, mode SafeMode) (*Connection, error) {
+	driver, connStr, err := ParseConnectionString(dbType, connectionString)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse connection string: %w", err)
+	}
+
+	switch dbType {
+	case PostgreSQL:
+		connStr, err = postgresSafeModeDSN(connStr, mode)
+	case MariaDB:
+		var mariaDB bool
+
+		if mariaDB, err = probeMariaDBServer(ctx, driver, connStr); err == nil {
+			connStr, err = mysqlSafeModeDSN(connStr, mode, mariaDB)
+		}
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply safe mode: %w", err)
+	}
+
+	return open(ctx, driver, connStr, dbType)
+}
+
+func open(ctx context.Context, driver, connStr string, dbType DatabaseType) (*Connection, error) {
 	db, err := sql.Open(driver, connStr)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database connection: %w", err)
 	}
 
-	db.SetMaxOpenConns(25)
+	db.SetMaxOpenConns(MaxOpenConnections)
 	db.SetMaxIdleConns(5)
 	db.SetConnMaxLifetime(5 * time.Minute)
 
@@ -48,10 +99,25 @@ func ConnectWithType(ctx context.Context, connectionString string, dbType Databa
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	return &Connection{db: db, dbType: dbType}, nil
+	conn := &Connection{db: db, dbType: dbType}
+
+	if dbType == MariaDB {
+		if conn.mariaDB, err = isMariaDBServer(ctx, db); err != nil {
+			_ = db.Close()
+			return nil, err
+		}
+	}
+
+	return conn, nil
 }
 
+// Close ends a snapshot transaction, or closes the pool of a connection
+// opened with Connect.
 func (c *Connection) Close() error {
+	if c.tx != nil {
+		return c.tx.Rollback()
+	}
+
 	if c.db != nil {
 		return c.db.Close()
 	}
@@ -66,3 +132,96 @@ func (c *Connection) DB() *sql.DB {
 func (c *Connection) DatabaseType() DatabaseType {
 	return c.dbType
 }
+
+func (c *Connection) queryer() queryer {
+	if c.tx != nil {
+		return c.tx
+	}
+
+	return c.db
+}
+
+// BeginSnapshot starts a read-only REPEATABLE READ transaction and returns a
+// Connection that runs every query inside it, along with the snapshot it
+// reads. On PostgreSQL the snapshot is exported with pg_export_snapshot so
+// that JoinSnapshot can open further transactions that see exactly the same
+// data; it stays importable until the returned Connection is closed, so the
+// exporting transaction is exempted from a safe mode
+// idle_in_transaction_session_timeout while it waits on the others. MariaDB
+// cannot export snapshots, so the ID is left empty.
+func (c *Connection) BeginSnapshot(ctx context.Context) (*Connection, *models.Snapshot, error) {
+	tx, err := c.db.BeginTx(ctx, snapshotTxOptions)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to begin snapshot transaction: %w", err)
+	}
+
+	snapshot := &models.Snapshot{}
+
+	switch c.dbType {
+	case PostgreSQL:
+		err = tx.QueryRowContext(ctx, "SELECT pg_export_snapshot(), transaction_timestamp()").Scan(&snapshot.ID, &snapshot.TakenAt)
+		if err == nil {
+			_, err = tx.ExecContext(ctx, "SET LOCAL idle_in_transaction_session_timeout = 0")
+		}
+	default:
+		var takenAt int64
+
+		err = tx.QueryRowContext(ctx, "SELECT UNIX_TIMESTAMP()").Scan(&takenAt)
+		snapshot.TakenAt = time.Unix(takenAt, 0)
+	}
+
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, nil, fmt.Errorf("failed to export snapshot: %w", err)
+	}
+
+	return &Connection{db: c.db, tx: tx, dbType: c.dbType, mariaDB: c.mariaDB}, snapshot, nil
+}
+
+// JoinSnapshot starts a read-only REPEATABLE READ transaction that reads the
+// PostgreSQL snapshot exported by BeginSnapshot.
+func (c *Connection) JoinSnapshot(ctx context.Context, snapshotID string) (*Connection, error) {
+	if c.dbType != PostgreSQL {
+		return nil, fmt.Errorf("%s does not support shared snapshots", c.dbType)
+	}
+
+	tx, err := c.db.BeginTx(ctx, snapshotTxOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin snapshot transaction: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, "SET TRANSACTION SNAPSHOT "+pq.QuoteLiteral(snapshotID)); err != nil {
+		_ = tx.Rollback()
+		return nil, fmt.Errorf("failed to import snapshot %s: %w", snapshotID, err)
+	}
+
+	return &Connection{db: c.db, tx: tx, dbType: c.dbType, mariaDB: c.mariaDB}, nil
+}
+
+// Guard runs fn under a savepoint of the snapshot transaction. If fn fails,
+// the transaction is rolled back to the savepoint: on PostgreSQL an error
+// aborts the whole transaction, and this keeps it usable for later queries.
+// On a plain connection fn runs as is.
+func (c *Connection) Guard(ctx context.Context, fn func() error) error {
+	if c.tx == nil {
+		return fn()
+	}
+
+	if _, err := c.tx.ExecContext(ctx, "SAVEPOINT guard"); err != nil {
+		return fmt.Errorf("failed to set savepoint: %w", err)
+	}
+
+	if err := fn(); err != nil {
+		if _, rollbackErr := c.tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT guard"); rollbackErr != nil {
+			return fmt.Errorf("failed to roll back to savepoint after %v: %w", err, rollbackErr)
+		}
+
+		return err
+	}
+
+	if _, err := c.tx.ExecContext(ctx, "RELEASE SAVEPOINT guard"); err != nil {
+		return fmt.Errorf("failed to release savepoint: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package analyzer
+
+import (
+	"errors"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+)
+
+// IsTimeout reports whether err came from a server-side time limit: on
+// PostgreSQL query_canceled (statement_timeout) or lock_not_available
+// (lock_timeout); on MariaDB ER_STATEMENT_TIMEOUT (max_statement_time); on
+// MySQL ER_QUERY_TIMEOUT (max_execution_time); or ER_LOCK_WAIT_TIMEOUT on
+// either.
+func IsTimeout(err error) bool {
+	var (
+		pqErr    *pq.Error
+		mysqlErr *mysql.MySQLError
+	)
+
+	switch {
+	case errors.As(err, &pqErr):
+		return pqErr.Code == "57014" || pqErr.Code == "55P03"
+	case errors.As(err, &mysqlErr):
+		return mysqlErr.Number == 1969 || mysqlErr.Number == 3024 || mysqlErr.Number == 1205
+	default:
+		return false
+	}
+}
+
+// IsPermissionDenied reports whether err came from missing privileges: on
+// PostgreSQL insufficient_privilege; on MariaDB and MySQL
+// ER_TABLEACCESS_DENIED_ERROR, ER_COLUMNACCESS_DENIED_ERROR or
+// ER_DBACCESS_DENIED_ERROR.
+func IsPermissionDenied(err error) bool {
+	var (
+		pqErr    *pq.Error
+		mysqlErr *mysql.MySQLError
+	)
+
+	switch {
+	case errors.As(err, &pqErr):
+		return pqErr.Code == "42501"
+	case errors.As(err, &mysqlErr):
+		return mysqlErr.Number == 1142 || mysqlErr.Number == 1143 || mysqlErr.Number == 1044
+	default:
+		return false
+	}
+}
@@ -3,6 +3,7 @@ package analyzer
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/orchard9/pg-goer/pkg/models"
 )
@@ -24,8 +25,49 @@ type DatabaseAnalyzer interface {
 	// GetTriggers returns all triggers for a specific table
 	GetTriggers(ctx context.Context, table *models.Table) ([]models.Trigger, error)
 
-	// GetTableRowCounts returns row counts for the specified tables
-	GetTableRowCounts(ctx context.Context, tables []models.Table) (map[string]int64, error)
+	// GetConstraints returns all constraints for a specific table
+	GetConstraints(ctx context.Context, table *models.Table) ([]models.Constraint, error)
+
+	// GetPolicies returns the row-level security policies for a table
+	GetPolicies(ctx context.Context, table *models.Table) ([]models.Policy, error)
+
+	// The ...ForTables variants fetch the same metadata for many tables with
+	// one query per object kind. Results are keyed by "schema.table"; tables
+	// with nothing to report have no entry.
+
+	// GetColumnsForTables returns the columns of the specified tables
+	GetColumnsForTables(ctx context.Context, tables []models.Table) (map[string][]models.Column, error)
+
+	// GetForeignKeysForTables returns the foreign keys of the specified tables
+	GetForeignKeysForTables(ctx context.Context, tables []models.Table) (map[string][]models.ForeignKey, error)
+
+	// GetIndexesForTables returns the indexes of the specified tables
+	GetIndexesForTables(ctx context.Context, tables []models.Table) (map[string][]models.Index, error)
+
+	// GetTriggersForTables returns the triggers of the specified tables
+	GetTriggersForTables(ctx context.Context, tables []models.Table) (map[string][]models.Trigger, error)
+
+	// GetConstraintsForTables returns the constraints of the specified tables
+	GetConstraintsForTables(ctx context.Context, tables []models.Table) (map[string][]models.Constraint, error)
+
+	// GetPoliciesForTables returns the row-level security policies of the
+	// specified tables
+	GetPoliciesForTables(ctx context.Context, tables []models.Table) (map[string][]models.Policy, error)
+
+	// GetDatabaseInfo returns the server version, database properties,
+	// non-default settings and the specified schemas with their owners
+	GetDatabaseInfo(ctx context.Context, schemas []string) (*models.DatabaseInfo, error)
+
+	// GetDatabaseSize returns the on-disk size of the connected database in bytes
+	GetDatabaseSize(ctx context.Context) (int64, error)
+
+	// GetTableRowCounts returns row counts for the specified tables, keyed by
+	// "schema.table", using the method in opts
+	GetTableRowCounts(ctx context.Context, tables []models.Table, opts RowCountOptions) (map[string]models.RowCount, error)
+
+	// GetTableStats returns activity and maintenance statistics for the
+	// specified tables, keyed by "schema.table"
+	GetTableStats(ctx context.Context, tables []models.Table) (map[string]models.TableStats, error)
 
 	// GetExtensions returns all database extensions (PostgreSQL specific)
 	GetExtensions(ctx context.Context) ([]models.Extension, error)
@@ -33,10 +75,73 @@ type DatabaseAnalyzer interface {
 	// GetViews returns all views in the specified schemas
 	GetViews(ctx context.Context, schemas []string) ([]models.View, error)
 
+	// GetViewColumns returns the output columns of a specific view
+	GetViewColumns(ctx context.Context, view *models.View) ([]models.Column, error)
+
+	// GetViewDependencies returns the relations a specific view reads from
+	GetViewDependencies(ctx context.Context, view *models.View) ([]models.Dependency, error)
+
+	// GetViewTriggers returns all triggers on a specific view
+	GetViewTriggers(ctx context.Context, view *models.View) ([]models.Trigger, error)
+
+	// GetMaterializedViews returns all materialized views in the specified schemas
+	GetMaterializedViews(ctx context.Context, schemas []string) ([]models.MaterializedView, error)
+
+	// GetMaterializedViewColumns returns the columns of a specific materialized view
+	GetMaterializedViewColumns(ctx context.Context, view *models.MaterializedView) ([]models.Column, error)
+
+	// GetMaterializedViewIndexes returns all indexes on a specific materialized view
+	GetMaterializedViewIndexes(ctx context.Context, view *models.MaterializedView) ([]models.Index, error)
+
+	// GetMaterializedViewDependencies returns the relations a specific materialized view reads from
+	GetMaterializedViewDependencies(ctx context.Context, view *models.MaterializedView) ([]models.Dependency, error)
+
+	// GetTypes returns all user-defined types in the specified schemas
+	GetTypes(ctx context.Context, schemas []string) ([]models.Type, error)
+
+	// GetRoutines returns all functions, procedures and aggregates in the
+	// specified schemas; routine bodies are only read when includeSource is set
+	GetRoutines(ctx context.Context, schemas []string, includeSource bool) ([]models.Routine, error)
+
+	// GetGrants returns the privileges held on tables, columns, sequences,
+	// functions and schemas in the specified schemas
+	GetGrants(ctx context.Context, schemas []string) ([]models.Grant, error)
+
+	// GetEventTriggers returns all event triggers in the database
+	GetEventTriggers(ctx context.Context) ([]models.EventTrigger, error)
+
+	// GetRules returns the rewrite rules on tables and views in the specified
+	// schemas, excluding the _RETURN rules that define views
+	GetRules(ctx context.Context, schemas []string) ([]models.Rule, error)
+
+	// GetPublications returns all logical replication publications and the
+	// tables they publish
+	GetPublications(ctx context.Context) ([]models.Publication, error)
+
+	// GetForeignDataWrappers returns all foreign data wrappers in the database
+	GetForeignDataWrappers(ctx context.Context) ([]models.ForeignDataWrapper, error)
+
+	// GetForeignServers returns all foreign servers with their user mappings;
+	// credentials are removed from their options
+	GetForeignServers(ctx context.Context) ([]models.ForeignServer, error)
+
+	// GetForeignTables returns all foreign tables in the specified schemas
+	GetForeignTables(ctx context.Context, schemas []string) ([]models.ForeignTable, error)
+
+	// GetForeignTableColumns returns the columns of a specific foreign table
+	GetForeignTableColumns(ctx context.Context, table *models.ForeignTable) ([]models.Column, error)
+
 	// GetSequences returns all sequences in the specified schemas
 	GetSequences(ctx context.Context, schemas []string) ([]models.Sequence, error)
 }
 
+// RowCountOptions controls how GetTableRowCounts counts rows. Timeout bounds
+// each exact or sampled count; a table that takes longer keeps its estimate.
+type RowCountOptions struct {
+	Method  models.RowCountMethod
+	Timeout time.Duration
+}
+
 // NewDatabaseAnalyzer creates a new analyzer based on the database type
 func NewDatabaseAnalyzer(dbType DatabaseType, conn *Connection) (DatabaseAnalyzer, error) {
 	switch dbType {
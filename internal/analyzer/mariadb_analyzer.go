@@ -4,7 +4,9 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/orchard9/pg-goer/pkg/models"
 )
@@ -16,62 +18,134 @@ type MariaDBAnalyzer struct {
 func (a *MariaDBAnalyzer) GetTables(ctx context.Context, schemas []string) ([]models.Table, error) {
 	query := a.buildTableQuery(schemas)
 
-	return querySchemaObjects(ctx, a.conn.db, query, schemas, func() models.Table { return models.Table{} },
-		func(item *models.Table) []interface{} { return []interface{}{&item.Schema, &item.Name} },
+	return querySchemaObjects(ctx, a.conn.queryer(), query, schemas, func() models.Table { return models.Table{} },
+		func(item *models.Table) []interface{} {
+			return []interface{}{
+				&item.Schema, &item.Name, &item.Description,
+				&item.PartitionStrategy, &item.PartitionKey, &item.PartitionOf, &item.PartitionBound,
+				&item.RowSecurity, &item.ForceRowSecurity,
+				&item.HeapSizeBytes, &item.ToastSizeBytes, &item.IndexesSizeBytes, &item.TotalSizeBytes,
+			}
+		},
 		"tables")
 }
 
 func (a *MariaDBAnalyzer) buildTableQuery(schemas []string) string {
 	return a.buildSchemaFilterQuery(
-		`SELECT table_schema AS schema_name, table_name 
-		 FROM information_schema.tables 
-		 WHERE table_type = 'BASE TABLE'`,
-		"table_schema",
-		"table_schema, table_name",
+		`SELECT t.table_schema AS schema_name, t.table_name, t.table_comment AS description, 
+		        COALESCE((SELECT MAX(p.partition_method) FROM information_schema.partitions p 
+		                  WHERE p.table_schema = t.table_schema AND p.table_name = t.table_name), '') AS partition_strategy, 
+		        COALESCE((SELECT MAX(p.partition_expression) FROM information_schema.partitions p 
+		                  WHERE p.table_schema = t.table_schema AND p.table_name = t.table_name), '') AS partition_key, 
+		        '' AS partition_of, 
+		        '' AS partition_bound, 
+		        false AS row_security, 
+		        false AS force_row_security, 
+		        COALESCE(t.data_length, 0) AS heap_size_bytes, 
+		        0 AS toast_size_bytes, 
+		        COALESCE(t.index_length, 0) AS indexes_size_bytes, 
+		        COALESCE(t.data_length, 0) + COALESCE(t.index_length, 0) AS total_size_bytes 
+		 FROM information_schema.tables t 
+		 WHERE t.table_type = 'BASE TABLE'`,
+		"t.table_schema",
+		"t.table_schema, t.table_name",
 		schemas,
 	)
 }
 
 func (a *MariaDBAnalyzer) GetColumns(ctx context.Context, table *models.Table) ([]models.Column, error) {
+	key := table.Schema + "." + table.Name
+
+	columns, err := a.queryColumns(ctx, []string{key})
+	if err != nil {
+		return nil, err
+	}
+
+	return columns[key], nil
+}
+
+// GetColumnsForTables fetches the columns of every listed table in one query.
+func (a *MariaDBAnalyzer) GetColumnsForTables(ctx context.Context, tables []models.Table) (map[string][]models.Column, error) {
+	return a.queryColumns(ctx, qualifiedNames(tables))
+}
+
+// queryColumns returns the columns of the named tables, keyed by
+// "schema.name".
+func (a *MariaDBAnalyzer) queryColumns(ctx context.Context, relations []string) (map[string][]models.Column, error) {
+	columns := make(map[string][]models.Column)
+
+	if len(relations) == 0 {
+		return columns, nil
+	}
+
+	filter, args := qualifiedNameFilter(relations)
+
 	query := `
 		SELECT 
+			CONCAT(c.table_schema, '.', c.table_name) AS qualified_name,
 			c.column_name,
 			c.data_type,
 			c.is_nullable,
 			c.column_default,
 			c.character_maximum_length,
+			CASE
+				WHEN c.data_type IN ('decimal', 'numeric') THEN c.numeric_precision
+				WHEN c.datetime_precision > 0 THEN c.datetime_precision
+			END AS column_precision,
+			CASE WHEN c.data_type IN ('decimal', 'numeric') THEN c.numeric_scale END AS column_scale,
+			CASE WHEN c.collation_name <> t.table_collation THEN c.collation_name ELSE '' END AS column_collation,
+			CASE WHEN c.extra LIKE '%auto_increment%' THEN 'AUTO_INCREMENT' ELSE '' END AS identity,
+			CASE
+				WHEN c.extra LIKE '%STORED GENERATED%' OR c.extra = 'PERSISTENT' THEN 'STORED'
+				WHEN c.extra LIKE '%VIRTUAL GENERATED%' OR c.extra = 'VIRTUAL' THEN 'VIRTUAL'
+				ELSE ''
+			END AS generated_kind,
+			COALESCE(c.generation_expression, '') AS generation_expression,
+			c.column_comment AS description,
 			CASE WHEN c.column_key = 'PRI' THEN true ELSE false END AS is_primary_key,
 			CASE WHEN c.column_key IN ('UNI', 'PRI') THEN true ELSE false END AS is_unique
 		FROM 
 			information_schema.columns c
+		JOIN 
+			information_schema.tables t 
+			  ON t.table_schema = c.table_schema 
+			  AND t.table_name = c.table_name
 		WHERE 
-			c.table_schema = ?
-			AND c.table_name = ?
+			CONCAT(c.table_schema, '.', c.table_name) ` + filter + `
 		ORDER BY 
-			c.ordinal_position`
+			c.table_schema, c.table_name, c.ordinal_position`
 
-	rows, err := a.conn.db.QueryContext(ctx, query, table.Schema, table.Name)
+	rows, err := a.conn.queryer().QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query columns: %w", err)
 	}
 	defer rows.Close()
 
-	var columns []models.Column
-
 	for rows.Next() {
 		var (
-			col          models.Column
-			isNullable   string
-			defaultValue sql.NullString
-			maxLength    sql.NullInt64
+			qualifiedName string
+			col           models.Column
+			isNullable    string
+			defaultValue  sql.NullString
+			maxLength     sql.NullInt64
+			precision     sql.NullInt64
+			scale         sql.NullInt64
 		)
 
 		if err := rows.Scan(
+			&qualifiedName,
 			&col.Name,
 			&col.DataType,
 			&isNullable,
 			&defaultValue,
 			&maxLength,
+			&precision,
+			&scale,
+			&col.Collation,
+			&col.Identity,
+			&col.Generated,
+			&col.GenerationExpression,
+			&col.Description,
 			&col.IsPrimaryKey,
 			&col.IsUnique,
 		); err != nil {
@@ -89,7 +163,10 @@ func (a *MariaDBAnalyzer) GetColumns(ctx context.Context, table *models.Table) (
 			col.MaxLength = &length
 		}
 
-		columns = append(columns, col)
+		col.Precision = nullIntPtr(precision)
+		col.Scale = nullIntPtr(scale)
+
+		columns[qualifiedName] = append(columns[qualifiedName], col)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -100,8 +177,36 @@ func (a *MariaDBAnalyzer) GetColumns(ctx context.Context, table *models.Table) (
 }
 
 func (a *MariaDBAnalyzer) GetForeignKeys(ctx context.Context, table *models.Table) ([]models.ForeignKey, error) {
+	key := table.Schema + "." + table.Name
+
+	foreignKeys, err := a.queryForeignKeys(ctx, []string{key})
+	if err != nil {
+		return nil, err
+	}
+
+	return foreignKeys[key], nil
+}
+
+// GetForeignKeysForTables fetches the foreign keys of every listed table in one query.
+func (a *MariaDBAnalyzer) GetForeignKeysForTables(ctx context.Context, tables []models.Table) (map[string][]models.ForeignKey, error) {
+	return a.queryForeignKeys(ctx, qualifiedNames(tables))
+}
+
+// queryForeignKeys returns the foreign keys of the named tables, keyed by
+// "schema.name".
+func (a *MariaDBAnalyzer) queryForeignKeys(ctx context.Context, relations []string) (map[string][]models.ForeignKey, error) {
+	foreignKeys := make(map[string][]models.ForeignKey)
+
+	if len(relations) == 0 {
+		return foreignKeys, nil
+	}
+
+	filter, args := qualifiedNameFilter(relations)
+
 	query := `
 		SELECT 
+			CONCAT(tc.table_schema, '.', tc.table_name) AS qualified_name,
+			tc.table_name,
 			tc.constraint_name,
 			kcu.column_name,
 			kcu.referenced_table_schema,
@@ -115,47 +220,72 @@ func (a *MariaDBAnalyzer) GetForeignKeys(ctx context.Context, table *models.Tabl
 			information_schema.key_column_usage AS kcu
 			ON tc.constraint_name = kcu.constraint_name
 			AND tc.table_schema = kcu.table_schema
+			AND tc.table_name = kcu.table_name
 		JOIN 
 			information_schema.referential_constraints AS rc
 			ON tc.constraint_name = rc.constraint_name
 			AND tc.table_schema = rc.constraint_schema
+			AND tc.table_name = rc.table_name
 		WHERE 
 			tc.constraint_type = 'FOREIGN KEY' 
-			AND tc.table_schema = ?
-			AND tc.table_name = ?
+			AND CONCAT(tc.table_schema, '.', tc.table_name) ` + filter + `
 		ORDER BY 
-			tc.constraint_name, kcu.ordinal_position`
+			tc.table_schema, tc.table_name, tc.constraint_name, kcu.ordinal_position`
 
-	rows, err := a.conn.db.QueryContext(ctx, query, table.Schema, table.Name)
+	rows, err := a.conn.queryer().QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query foreign keys: %w", err)
 	}
 	defer rows.Close()
 
-	var foreignKeys []models.ForeignKey
-
+	// Rows arrive one per column; composite keys are folded into a single
+	// ForeignKey, relying on the ORDER BY to keep their columns adjacent.
 	for rows.Next() {
 		var (
-			fk            models.ForeignKey
-			foreignSchema string
+			qualifiedName    string
+			tableName        string
+			name             string
+			sourceColumn     string
+			foreignSchema    string
+			foreignTable     string
+			referencedColumn string
+			onDelete         string
+			onUpdate         string
 		)
 
 		if err := rows.Scan(
-			&fk.Name,
-			&fk.SourceColumn,
+			&qualifiedName,
+			&tableName,
+			&name,
+			&sourceColumn,
 			&foreignSchema,
-			&fk.ReferencedTable,
-			&fk.ReferencedColumn,
-			&fk.OnDelete,
-			&fk.OnUpdate,
+			&foreignTable,
+			&referencedColumn,
+			&onDelete,
+			&onUpdate,
 		); err != nil {
 			return nil, fmt.Errorf("failed to scan foreign key row: %w", err)
 		}
 
-		fk.SourceTable = table.Name
-		fk.ReferencedTable = fmt.Sprintf("%s.%s", foreignSchema, fk.ReferencedTable)
+		tableKeys := foreignKeys[qualifiedName]
 
-		foreignKeys = append(foreignKeys, fk)
+		if n := len(tableKeys); n > 0 && tableKeys[n-1].Name == name {
+			fk := &tableKeys[n-1]
+			fk.SourceColumns = append(fk.SourceColumns, sourceColumn)
+			fk.ReferencedColumns = append(fk.ReferencedColumns, referencedColumn)
+
+			continue
+		}
+
+		foreignKeys[qualifiedName] = append(tableKeys, models.ForeignKey{
+			Name:              name,
+			SourceTable:       tableName,
+			SourceColumns:     []string{sourceColumn},
+			ReferencedTable:   fmt.Sprintf("%s.%s", foreignSchema, foreignTable),
+			ReferencedColumns: []string{referencedColumn},
+			OnDelete:          onDelete,
+			OnUpdate:          onUpdate,
+		})
 	}
 
 	if err := rows.Err(); err != nil {
@@ -165,93 +295,388 @@ func (a *MariaDBAnalyzer) GetForeignKeys(ctx context.Context, table *models.Tabl
 	return foreignKeys, nil
 }
 
-func (a *MariaDBAnalyzer) GetTableRowCounts(ctx context.Context, tables []models.Table) (map[string]int64, error) {
-	if len(tables) == 0 {
-		return make(map[string]int64), nil
+func (a *MariaDBAnalyzer) GetConstraints(ctx context.Context, table *models.Table) ([]models.Constraint, error) {
+	key := table.Schema + "." + table.Name
+
+	constraints, err := a.queryConstraints(ctx, []string{key})
+	if err != nil {
+		return nil, err
+	}
+
+	return constraints[key], nil
+}
+
+// GetConstraintsForTables fetches the constraints of every listed table in one query.
+func (a *MariaDBAnalyzer) GetConstraintsForTables(ctx context.Context, tables []models.Table) (map[string][]models.Constraint, error) {
+	return a.queryConstraints(ctx, qualifiedNames(tables))
+}
+
+// queryConstraints returns the constraints of the named tables, keyed by
+// "schema.name".
+func (a *MariaDBAnalyzer) queryConstraints(ctx context.Context, relations []string) (map[string][]models.Constraint, error) {
+	constraints := make(map[string][]models.Constraint)
+
+	if len(relations) == 0 {
+		return constraints, nil
+	}
+
+	filter, args := qualifiedNameFilter(relations)
+
+	// MariaDB names column-level CHECKs after their column, so the same name
+	// can appear on several tables of a schema. MySQL keeps CHECK names unique
+	// per schema and has no table_name column in check_constraints.
+	checkJoin := ""
+	if a.conn.mariaDB {
+		checkJoin = "AND tc.table_name = cc.table_name"
+	}
+
+	query := `
+		SELECT 
+			CONCAT(tc.table_schema, '.', tc.table_name) AS qualified_name,
+			tc.constraint_name,
+			tc.constraint_type,
+			COALESCE(GROUP_CONCAT(kcu.column_name ORDER BY kcu.ordinal_position SEPARATOR ','), '') AS columns,
+			MAX(kcu.referenced_table_schema) AS referenced_table_schema,
+			MAX(kcu.referenced_table_name) AS referenced_table_name,
+			COALESCE(GROUP_CONCAT(kcu.referenced_column_name ORDER BY kcu.ordinal_position SEPARATOR ','), '') AS referenced_columns,
+			cc.check_clause
+		FROM 
+			information_schema.table_constraints AS tc
+		LEFT JOIN 
+			information_schema.key_column_usage AS kcu
+			ON tc.constraint_name = kcu.constraint_name
+			AND tc.table_schema = kcu.table_schema
+			AND tc.table_name = kcu.table_name
+		LEFT JOIN 
+			information_schema.check_constraints AS cc
+			ON tc.constraint_name = cc.constraint_name
+			AND tc.table_schema = cc.constraint_schema
+			` + checkJoin + `
+		WHERE 
+			CONCAT(tc.table_schema, '.', tc.table_name) ` + filter + `
+		GROUP BY 
+			tc.table_schema, tc.table_name, tc.constraint_name, tc.constraint_type, cc.check_clause
+		ORDER BY 
+			tc.table_schema, tc.table_name, FIELD(tc.constraint_type, 'PRIMARY KEY', 'UNIQUE', 'FOREIGN KEY', 'CHECK'), tc.constraint_name`
+
+	rows, err := a.conn.queryer().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query constraints: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			qualifiedName     string
+			constraint        models.Constraint
+			columns           string
+			referencedSchema  sql.NullString
+			referencedTable   sql.NullString
+			referencedColumns string
+			checkClause       sql.NullString
+		)
+
+		if err := rows.Scan(
+			&qualifiedName,
+			&constraint.Name,
+			&constraint.Type,
+			&columns,
+			&referencedSchema,
+			&referencedTable,
+			&referencedColumns,
+			&checkClause,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan constraint row: %w", err)
+		}
+
+		if columns != "" {
+			constraint.Columns = strings.Split(columns, ",")
+		}
+
+		// MariaDB has no pg_get_constraintdef equivalent, so the definition is
+		// rebuilt from the catalog. Constraints are never deferred or NOT VALID.
+		switch {
+		case checkClause.Valid:
+			constraint.Definition = fmt.Sprintf("CHECK (%s)", checkClause.String)
+		case constraint.Type == "FOREIGN KEY":
+			constraint.Definition = fmt.Sprintf("FOREIGN KEY (%s) REFERENCES %s.%s(%s)",
+				strings.Join(constraint.Columns, ", "), referencedSchema.String, referencedTable.String,
+				strings.ReplaceAll(referencedColumns, ",", ", "))
+		default:
+			constraint.Definition = fmt.Sprintf("%s (%s)", constraint.Type, strings.Join(constraint.Columns, ", "))
+		}
+
+		constraint.IsValidated = true
+
+		constraints[qualifiedName] = append(constraints[qualifiedName], constraint)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating constraint rows: %w", err)
+	}
+
+	return constraints, nil
+}
+
+func (a *MariaDBAnalyzer) GetPolicies(_ context.Context, _ *models.Table) ([]models.Policy, error) {
+	// MariaDB doesn't have row-level security
+	// Return empty slice
+	return []models.Policy{}, nil
+}
+
+func (a *MariaDBAnalyzer) GetPoliciesForTables(_ context.Context, _ []models.Table) (map[string][]models.Policy, error) {
+	// MariaDB doesn't have row-level security
+	// Return empty map
+	return make(map[string][]models.Policy), nil
+}
+
+// GetTableRowCounts starts from information_schema.tables.table_rows, which
+// is exact for MyISAM and Aria but only a rough estimate for InnoDB. The exact
+// method replaces it with COUNT(*) bounded by max_statement_time on MariaDB or
+// max_execution_time on MySQL; a count that times out, or on a table the role
+// cannot read, keeps the estimate. Neither has TABLESAMPLE, so the sample
+// method keeps the estimate.
+func (a *MariaDBAnalyzer) GetTableRowCounts(ctx context.Context, tables []models.Table, opts RowCountOptions) (map[string]models.RowCount, error) {
+	rowCounts := make(map[string]models.RowCount)
+
+	if len(tables) == 0 || opts.Method == models.RowCountNone {
+		return rowCounts, nil
 	}
 
-	rowCounts := make(map[string]int64)
+	estimates, err := a.estimateRowCounts(ctx, tables)
+	if err != nil {
+		return nil, err
+	}
 
-	// For MariaDB, we'll use table_rows from information_schema.tables
-	// Note: This is an approximation for InnoDB tables
-	for _, table := range tables {
-		query := `
-			SELECT COALESCE(table_rows, 0) 
-			FROM information_schema.tables 
-			WHERE table_schema = ? AND table_name = ?`
+	for i := range tables {
+		table := &tables[i]
+		key := table.Schema + "." + table.Name
 
-		var rowCount int64
-		err := a.conn.db.QueryRowContext(ctx, query, table.Schema, table.Name).Scan(&rowCount)
-		if err != nil {
-			// If we can't get the estimate, try COUNT(*) as fallback
-			// Note: This is safe as table names are validated by the database schema
-			countQuery := "SELECT COUNT(*) FROM `" + table.Schema + "`.`" + table.Name + "`"
-			err = a.conn.db.QueryRowContext(ctx, countQuery).Scan(&rowCount)
-			if err != nil {
-				// If both fail, set to 0
-				rowCount = 0
+		rowCount, exists := estimates[key]
+		if !exists {
+			continue
+		}
+
+		if opts.Method == models.RowCountExact {
+			count, err := a.countRows(ctx, table, opts)
+
+			switch {
+			case err == nil:
+				rowCount = count
+			case !IsTimeout(err) && !IsPermissionDenied(err):
+				return nil, err
 			}
 		}
 
-		rowCounts[table.Name] = rowCount
+		rowCounts[key] = rowCount
 	}
 
 	return rowCounts, nil
 }
 
+// estimateRowCounts reads table_rows for all tables in one query, keyed by
+// qualified name. MyISAM and Aria keep an exact count there.
+func (a *MariaDBAnalyzer) estimateRowCounts(ctx context.Context, tables []models.Table) (map[string]models.RowCount, error) {
+	filter, args := qualifiedNameFilter(qualifiedNames(tables))
+
+	query := `
+		SELECT CONCAT(table_schema, '.', table_name), COALESCE(table_rows, 0), COALESCE(engine, '')
+		FROM information_schema.tables
+		WHERE CONCAT(table_schema, '.', table_name) ` + filter
+
+	rows, err := a.conn.queryer().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query table row counts: %w", err)
+	}
+	defer rows.Close()
+
+	estimates := make(map[string]models.RowCount)
+
+	for rows.Next() {
+		var (
+			key      string
+			engine   string
+			rowCount = models.RowCount{Method: models.RowCountEstimate, Confidence: models.ConfidenceLow}
+		)
+
+		if err := rows.Scan(&key, &rowCount.Count, &engine); err != nil {
+			return nil, fmt.Errorf("failed to scan table row count: %w", err)
+		}
+
+		if engine == "MyISAM" || engine == "Aria" {
+			rowCount.Confidence = models.ConfidenceHigh
+		}
+
+		estimates[key] = rowCount
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating table row counts: %w", err)
+	}
+
+	return estimates, nil
+}
+
+func (a *MariaDBAnalyzer) countRows(ctx context.Context, table *models.Table, opts RowCountOptions) (models.RowCount, error) {
+	rowCount := models.RowCount{Method: models.RowCountExact, Confidence: models.ConfidenceHigh}
+	query := countRowsQuery(table, opts.Timeout, a.conn.mariaDB)
+
+	if err := a.conn.queryer().QueryRowContext(ctx, query).Scan(&rowCount.Count); err != nil {
+		return rowCount, fmt.Errorf("failed to count rows in %s.%s: %w", table.Schema, table.Name, err)
+	}
+
+	return rowCount, nil
+}
+
+// countRowsQuery builds a COUNT(*) over the table, limited to timeout when it
+// is set: MariaDB takes SET STATEMENT max_statement_time, in seconds, and
+// MySQL the MAX_EXECUTION_TIME optimizer hint, in milliseconds.
+func countRowsQuery(table *models.Table, timeout time.Duration, mariaDB bool) string {
+	relation := quoteMariaDBIdentifier(table.Schema) + "." + quoteMariaDBIdentifier(table.Name)
+
+	switch {
+	case timeout <= 0:
+		return "SELECT COUNT(*) FROM " + relation
+	case mariaDB:
+		return fmt.Sprintf("SET STATEMENT max_statement_time = %s FOR SELECT COUNT(*) FROM %s",
+			strconv.FormatFloat(timeout.Seconds(), 'f', -1, 64), relation)
+	default:
+		return fmt.Sprintf("SELECT /*+ MAX_EXECUTION_TIME(%d) */ COUNT(*) FROM %s", timeout.Milliseconds(), relation)
+	}
+}
+
+// qualifiedNameFilter builds an "IN (?, ...)" clause matching the given
+// "schema.name" values, with the values as its arguments.
+func qualifiedNameFilter(relations []string) (string, []interface{}) {
+	placeholders := make([]string, len(relations))
+	args := make([]interface{}, len(relations))
+
+	for i, relation := range relations {
+		placeholders[i] = "?"
+		args[i] = relation
+	}
+
+	return "IN (" + strings.Join(placeholders, ", ") + ")", args
+}
+
+func quoteMariaDBIdentifier(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
 func (a *MariaDBAnalyzer) GetIndexes(ctx context.Context, table *models.Table) ([]models.Index, error) {
+	key := table.Schema + "." + table.Name
+
+	indexes, err := a.queryIndexes(ctx, []string{key})
+	if err != nil {
+		return nil, err
+	}
+
+	return indexes[key], nil
+}
+
+// GetIndexesForTables fetches the indexes of every listed table in one query.
+func (a *MariaDBAnalyzer) GetIndexesForTables(ctx context.Context, tables []models.Table) (map[string][]models.Index, error) {
+	return a.queryIndexes(ctx, qualifiedNames(tables))
+}
+
+// queryIndexes returns the indexes of the named tables, keyed by
+// "schema.name". information_schema.statistics has one row per key part, in
+// index order; MySQL leaves COLUMN_NAME NULL for functional key parts and
+// reports their EXPRESSION instead, a column MariaDB does not have. Sizes
+// come from InnoDB's persistent statistics where they can be read.
+func (a *MariaDBAnalyzer) queryIndexes(ctx context.Context, relations []string) (map[string][]models.Index, error) {
+	indexes := make(map[string][]models.Index)
+
+	if len(relations) == 0 {
+		return indexes, nil
+	}
+
+	filter, args := qualifiedNameFilter(relations)
+
+	sizes, err := a.queryIndexSizes(ctx, filter, args)
+	if err != nil {
+		return nil, err
+	}
+
+	expression := "NULL"
+	if !a.conn.mariaDB {
+		expression = "s.expression"
+	}
+
 	query := `
-		SELECT DISTINCT
+		SELECT
+			CONCAT(s.table_schema, '.', s.table_name) AS qualified_name,
 			s.index_name,
-			CASE 
-				WHEN s.index_name = 'PRIMARY' THEN 'PRIMARY KEY'
-				WHEN s.non_unique = 0 THEN 'UNIQUE'
-				ELSE 'INDEX'
-			END AS index_type,
-			CASE WHEN s.index_name = 'PRIMARY' THEN true ELSE false END AS is_primary,
-			CASE WHEN s.non_unique = 0 THEN true ELSE false END AS is_unique,
+			s.non_unique,
 			s.index_type AS access_method,
-			GROUP_CONCAT(s.column_name ORDER BY s.seq_in_index SEPARATOR ',') AS columns
+			s.column_name,
+			` + expression + ` AS expression,
+			COALESCE(s.collation, 'A') AS collation
 		FROM 
 			information_schema.statistics s
 		WHERE 
-			s.table_schema = ?
-			AND s.table_name = ?
-		GROUP BY 
-			s.index_name, s.non_unique, s.index_type
+			CONCAT(s.table_schema, '.', s.table_name) ` + filter + `
 		ORDER BY 
-			is_primary DESC, is_unique DESC, s.index_name`
+			s.table_schema, s.table_name, s.index_name = 'PRIMARY' DESC, s.non_unique, s.index_name, s.seq_in_index`
 
-	rows, err := a.conn.db.QueryContext(ctx, query, table.Schema, table.Name)
+	rows, err := a.conn.queryer().QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query indexes: %w", err)
 	}
 	defer rows.Close()
 
-	var indexes []models.Index
-
 	for rows.Next() {
 		var (
-			idx     models.Index
-			columns string
+			qualifiedName string
+			name          string
+			nonUnique     int64
+			method        string
+			column        sql.NullString
+			expression    sql.NullString
+			collation     string
 		)
 
-		if err := rows.Scan(
-			&idx.Name,
-			&idx.Type,
-			&idx.IsPrimary,
-			&idx.IsUnique,
-			&idx.Method,
-			&columns,
-		); err != nil {
+		if err := rows.Scan(&qualifiedName, &name, &nonUnique, &method, &column, &expression, &collation); err != nil {
 			return nil, fmt.Errorf("failed to scan index row: %w", err)
 		}
 
-		if columns != "" {
-			idx.Columns = strings.Split(columns, ",")
+		tableIndexes := indexes[qualifiedName]
+		if len(tableIndexes) == 0 || tableIndexes[len(tableIndexes)-1].Name != name {
+			// MariaDB has no partial or invalid indexes
+			index := models.Index{
+				Name:      name,
+				Type:      "INDEX",
+				IsPrimary: name == "PRIMARY",
+				IsUnique:  nonUnique == 0,
+				Method:    method,
+				IsValid:   true,
+				SizeBytes: sizes[indexKey{qualifiedName, name}],
+			}
+
+			switch {
+			case index.IsPrimary:
+				index.Type = "PRIMARY KEY"
+			case index.IsUnique:
+				index.Type = "UNIQUE"
+			}
+
+			indexes[qualifiedName] = append(tableIndexes, index)
+			tableIndexes = indexes[qualifiedName]
 		}
 
-		indexes = append(indexes, idx)
+		idx := &tableIndexes[len(tableIndexes)-1]
+
+		// collation 'D' marks a descending key part
+		part := models.IndexKeyPart{Column: column.String, Descending: collation == "D"}
+		keyPart := column.String
+
+		if !column.Valid {
+			part.Expression = "(" + expression.String + ")"
+			keyPart = part.Expression
+		}
+
+		idx.Columns = append(idx.Columns, keyPart)
+		idx.KeyParts = append(idx.KeyParts, part)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -261,44 +686,133 @@ func (a *MariaDBAnalyzer) GetIndexes(ctx context.Context, table *models.Table) (
 	return indexes, nil
 }
 
+type indexKey struct {
+	table string // "schema.name"
+	index string
+}
+
+// queryIndexSizes reads index sizes from mysql.innodb_index_stats, whose
+// "size" statistic counts pages; partitions are stored as "table#P#name" and
+// summed into their table. The table is only readable with privileges on the
+// mysql schema, and covers InnoDB tables with persistent statistics only, so
+// without access the sizes are left empty rather than failing the query.
+func (a *MariaDBAnalyzer) queryIndexSizes(ctx context.Context, filter string, args []interface{}) (map[indexKey]int64, error) {
+	query := `
+		SELECT 
+			CONCAT(s.database_name, '.', SUBSTRING_INDEX(s.table_name, '#P#', 1)) AS qualified_name,
+			s.index_name,
+			SUM(s.stat_value) * @@innodb_page_size AS size_bytes
+		FROM 
+			mysql.innodb_index_stats s
+		WHERE 
+			s.stat_name = 'size'
+			AND CONCAT(s.database_name, '.', SUBSTRING_INDEX(s.table_name, '#P#', 1)) ` + filter + `
+		GROUP BY 
+			qualified_name, s.index_name`
+
+	sizes := make(map[indexKey]int64)
+
+	rows, err := a.conn.queryer().QueryContext(ctx, query, args...)
+	if IsPermissionDenied(err) {
+		return sizes, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to query index sizes: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			key  indexKey
+			size int64
+		)
+
+		if err := rows.Scan(&key.table, &key.index, &size); err != nil {
+			return nil, fmt.Errorf("failed to scan index size row: %w", err)
+		}
+
+		sizes[key] = size
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating index size rows: %w", err)
+	}
+
+	return sizes, nil
+}
+
+// GetTriggers lists a table's triggers in firing order. MariaDB triggers run
+// a statement body rather than a function, so Function stays empty and the
+// full CREATE TRIGGER statement is rebuilt into Definition.
 func (a *MariaDBAnalyzer) GetTriggers(ctx context.Context, table *models.Table) ([]models.Trigger, error) {
+	key := table.Schema + "." + table.Name
+
+	triggers, err := a.queryTriggers(ctx, []string{key})
+	if err != nil {
+		return nil, err
+	}
+
+	return triggers[key], nil
+}
+
+// GetTriggersForTables fetches the triggers of every listed table in one query.
+func (a *MariaDBAnalyzer) GetTriggersForTables(ctx context.Context, tables []models.Table) (map[string][]models.Trigger, error) {
+	return a.queryTriggers(ctx, qualifiedNames(tables))
+}
+
+// queryTriggers returns the triggers of the named tables, keyed by
+// "schema.name".
+func (a *MariaDBAnalyzer) queryTriggers(ctx context.Context, relations []string) (map[string][]models.Trigger, error) {
+	triggers := make(map[string][]models.Trigger)
+
+	if len(relations) == 0 {
+		return triggers, nil
+	}
+
+	filter, args := qualifiedNameFilter(relations)
+
 	query := `
 		SELECT 
+			CONCAT(t.event_object_schema, '.', t.event_object_table) AS qualified_name,
 			t.trigger_name,
 			t.action_timing AS timing,
 			t.event_manipulation AS event,
-			t.action_statement AS function_name,
-			'ROW' AS orientation  -- MariaDB triggers are always row-level
+			'ROW' AS orientation,  -- MariaDB triggers are always row-level
+			CONCAT('CREATE TRIGGER ', t.trigger_name, ' ', t.action_timing, ' ', t.event_manipulation, 
+			       ' ON ', t.event_object_table, ' FOR EACH ROW ', t.action_statement) AS definition,
+			t.action_order
 		FROM 
 			information_schema.triggers t
 		WHERE 
-			t.trigger_schema = ?
-			AND t.event_object_table = ?
+			CONCAT(t.event_object_schema, '.', t.event_object_table) ` + filter + `
 		ORDER BY 
-			t.trigger_name`
+			t.event_object_schema, t.event_object_table, t.event_manipulation, t.action_timing, t.action_order`
 
-	rows, err := a.conn.db.QueryContext(ctx, query, table.Schema, table.Name)
+	rows, err := a.conn.queryer().QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query triggers: %w", err)
 	}
 	defer rows.Close()
 
-	var triggers []models.Trigger
-
 	for rows.Next() {
-		var trigger models.Trigger
+		var qualifiedName string
+
+		trigger := models.Trigger{Enabled: models.TriggerEnabled}
 
 		if err := rows.Scan(
+			&qualifiedName,
 			&trigger.Name,
 			&trigger.Timing,
 			&trigger.Event,
-			&trigger.Function,
 			&trigger.Orientation,
+			&trigger.Definition,
+			&trigger.ActionOrder,
 		); err != nil {
 			return nil, fmt.Errorf("failed to scan trigger row: %w", err)
 		}
 
-		triggers = append(triggers, trigger)
+		triggers[qualifiedName] = append(triggers[qualifiedName], trigger)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -308,6 +822,144 @@ func (a *MariaDBAnalyzer) GetTriggers(ctx context.Context, table *models.Table)
 	return triggers, nil
 }
 
+// GetTableStats returns no statistics for MariaDB.
+func (a *MariaDBAnalyzer) GetTableStats(ctx context.Context, tables []models.Table) (map[string]models.TableStats, error) {
+	// MariaDB only keeps comparable counters when the userstat plugin is
+	// enabled, and has no vacuum or analyze history
+	// Return empty map
+	return make(map[string]models.TableStats), nil
+}
+
+func (a *MariaDBAnalyzer) GetDatabaseInfo(ctx context.Context, schemas []string) (*models.DatabaseInfo, error) {
+	query := `
+		SELECT 
+			VERSION(),
+			COALESCE(DATABASE(), ''),
+			@@character_set_database,
+			@@collation_database,
+			IF(@@time_zone = 'SYSTEM', @@system_time_zone, @@time_zone)`
+
+	// MariaDB databases have no owner or separate ctype
+	info := &models.DatabaseInfo{}
+
+	if err := a.conn.queryer().QueryRowContext(ctx, query).Scan(
+		&info.ServerVersion,
+		&info.Name,
+		&info.Encoding,
+		&info.Collation,
+		&info.TimeZone,
+	); err != nil {
+		return nil, fmt.Errorf("failed to query database info: %w", err)
+	}
+
+	info.Engine = "MySQL"
+	if strings.Contains(info.ServerVersion, "MariaDB") {
+		info.Engine = "MariaDB"
+	}
+
+	settings, err := a.getNonDefaultSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	info.Settings = settings
+
+	schemaQuery := a.buildSchemaFilterQuery(
+		`SELECT schema_name, '' AS owner, '' AS description 
+		 FROM information_schema.schemata WHERE true`,
+		"schema_name",
+		"schema_name",
+		schemas,
+	)
+
+	info.Schemas, err = querySchemaObjects(ctx, a.conn.queryer(), schemaQuery, schemas, func() models.SchemaInfo { return models.SchemaInfo{} },
+		func(item *models.SchemaInfo) []interface{} {
+			return []interface{}{&item.Name, &item.Owner, &item.Description}
+		},
+		"schemas")
+	if err != nil {
+		return nil, err
+	}
+
+	return info, nil
+}
+
+// getNonDefaultSettings lists the global variables set outside the compiled
+// defaults. MariaDB records the origin in information_schema.system_variables;
+// MySQL has no such table and records it in performance_schema.variables_info.
+func (a *MariaDBAnalyzer) getNonDefaultSettings(ctx context.Context) ([]models.Setting, error) {
+	query := `
+		SELECT 
+			LOWER(variable_name),
+			COALESCE(global_value, ''),
+			global_value_origin
+		FROM 
+			information_schema.system_variables
+		WHERE 
+			global_value_origin IN ('CONFIG', 'COMMAND-LINE', 'SQL')
+		ORDER BY 
+			variable_name`
+
+	if !a.conn.mariaDB {
+		query = `
+		SELECT 
+			LOWER(vi.variable_name),
+			COALESCE(gv.variable_value, ''),
+			vi.variable_source
+		FROM 
+			performance_schema.variables_info AS vi
+		JOIN 
+			performance_schema.global_variables AS gv
+			ON gv.variable_name = vi.variable_name
+		WHERE 
+			vi.variable_source <> 'COMPILED'
+		ORDER BY 
+			vi.variable_name`
+	}
+
+	rows, err := a.conn.queryer().QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query settings: %w", err)
+	}
+	defer rows.Close()
+
+	var settings []models.Setting
+
+	for rows.Next() {
+		var setting models.Setting
+
+		if err := rows.Scan(&setting.Name, &setting.Value, &setting.Source); err != nil {
+			return nil, fmt.Errorf("failed to scan setting row: %w", err)
+		}
+
+		settings = append(settings, setting)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating setting rows: %w", err)
+	}
+
+	return settings, nil
+}
+
+// GetDatabaseSize sums data and index lengths over the tables of the
+// connected database. MariaDB does not report per-index sizes without access
+// to mysql.innodb_index_stats, so Index.SizeBytes stays zero.
+func (a *MariaDBAnalyzer) GetDatabaseSize(ctx context.Context) (int64, error) {
+	query := `
+		SELECT COALESCE(SUM(data_length + index_length), 0) 
+		FROM information_schema.tables 
+		WHERE table_schema = DATABASE()`
+
+	var size int64
+
+	if err := a.conn.queryer().QueryRowContext(ctx, query).Scan(&size); err != nil {
+		return 0, fmt.Errorf("failed to query database size: %w", err)
+	}
+
+	return size, nil
+}
+
 func (a *MariaDBAnalyzer) GetExtensions(_ context.Context) ([]models.Extension, error) {
 	// MariaDB doesn't have extensions like PostgreSQL
 	// Return empty slice
@@ -317,14 +969,17 @@ func (a *MariaDBAnalyzer) GetExtensions(_ context.Context) ([]models.Extension,
 func (a *MariaDBAnalyzer) GetViews(ctx context.Context, schemas []string) ([]models.View, error) {
 	query := a.buildViewQuery(schemas)
 
-	return querySchemaObjects(ctx, a.conn.db, query, schemas, func() models.View { return models.View{} },
-		func(item *models.View) []interface{} { return []interface{}{&item.Schema, &item.Name} },
+	return querySchemaObjects(ctx, a.conn.queryer(), query, schemas, func() models.View { return models.View{} },
+		func(item *models.View) []interface{} {
+			return []interface{}{&item.Schema, &item.Name, &item.Description, &item.Definition}
+		},
 		"views")
 }
 
 func (a *MariaDBAnalyzer) buildViewQuery(schemas []string) string {
 	return a.buildSchemaFilterQuery(
-		`SELECT table_schema AS schema_name, table_name AS view_name 
+		`SELECT table_schema AS schema_name, table_name AS view_name, '' AS description, 
+		        COALESCE(view_definition, '') AS definition 
 		 FROM information_schema.views WHERE true`,
 		"table_schema",
 		"table_schema, table_name",
@@ -332,6 +987,127 @@ func (a *MariaDBAnalyzer) buildViewQuery(schemas []string) string {
 	)
 }
 
+func (a *MariaDBAnalyzer) GetViewColumns(ctx context.Context, view *models.View) ([]models.Column, error) {
+	return a.GetColumns(ctx, &models.Table{Schema: view.Schema, Name: view.Name})
+}
+
+func (a *MariaDBAnalyzer) GetViewDependencies(_ context.Context, _ *models.View) ([]models.Dependency, error) {
+	// MariaDB doesn't expose view dependencies (no VIEW_TABLE_USAGE)
+	// Return empty slice
+	return []models.Dependency{}, nil
+}
+
+func (a *MariaDBAnalyzer) GetViewTriggers(_ context.Context, _ *models.View) ([]models.Trigger, error) {
+	// MariaDB doesn't allow triggers on views
+	// Return empty slice
+	return []models.Trigger{}, nil
+}
+
+func (a *MariaDBAnalyzer) GetMaterializedViews(_ context.Context, _ []string) ([]models.MaterializedView, error) {
+	// MariaDB doesn't have materialized views
+	// Return empty slice
+	return []models.MaterializedView{}, nil
+}
+
+func (a *MariaDBAnalyzer) GetMaterializedViewColumns(_ context.Context, _ *models.MaterializedView) ([]models.Column, error) {
+	return []models.Column{}, nil
+}
+
+func (a *MariaDBAnalyzer) GetMaterializedViewIndexes(_ context.Context, _ *models.MaterializedView) ([]models.Index, error) {
+	return []models.Index{}, nil
+}
+
+func (a *MariaDBAnalyzer) GetMaterializedViewDependencies(_ context.Context, _ *models.MaterializedView) ([]models.Dependency, error) {
+	return []models.Dependency{}, nil
+}
+
+func (a *MariaDBAnalyzer) GetTypes(_ context.Context, _ []string) ([]models.Type, error) {
+	// MariaDB has no user-defined types; ENUM and SET are declared per column
+	// Return empty slice
+	return []models.Type{}, nil
+}
+
+// GetRoutines returns stored functions and procedures. MariaDB has no
+// volatility classes, so Volatility reports whether the routine is DETERMINISTIC.
+func (a *MariaDBAnalyzer) GetRoutines(ctx context.Context, schemas []string, includeSource bool) ([]models.Routine, error) {
+	return querySchemaObjects(ctx, a.conn.queryer(), a.buildRoutineQuery(schemas, includeSource), schemas,
+		func() models.Routine { return models.Routine{} },
+		func(r *models.Routine) []interface{} {
+			return []interface{}{
+				&r.Schema, &r.Name, &r.Kind, &r.Arguments, &r.ReturnType, &r.Language,
+				&r.Volatility, &r.SecurityDefiner, &r.Owner, &r.Description, &r.Source,
+			}
+		},
+		"routines")
+}
+
+func (a *MariaDBAnalyzer) buildRoutineQuery(schemas []string, includeSource bool) string {
+	source := "''"
+	if includeSource {
+		source = "COALESCE(r.routine_definition, '')"
+	}
+
+	return a.buildSchemaFilterQuery(
+		`SELECT r.routine_schema, r.routine_name, LOWER(r.routine_type) AS kind, 
+		        COALESCE((SELECT GROUP_CONCAT(CONCAT_WS(' ', p.parameter_mode, p.parameter_name, p.dtd_identifier) 
+		                                      ORDER BY p.ordinal_position SEPARATOR ', ') 
+		                  FROM information_schema.parameters p 
+		                  WHERE p.specific_schema = r.routine_schema AND p.specific_name = r.specific_name 
+		                    AND p.routine_type = r.routine_type AND p.ordinal_position > 0), '') AS arguments, 
+		        COALESCE(r.dtd_identifier, '') AS return_type, 
+		        r.routine_body AS language, 
+		        IF(r.is_deterministic = 'YES', 'DETERMINISTIC', 'NOT DETERMINISTIC') AS volatility, 
+		        r.security_type = 'DEFINER' AS security_definer, 
+		        r.definer AS owner, 
+		        r.routine_comment AS description, 
+		        `+source+` AS source 
+		 FROM information_schema.routines r WHERE true`,
+		"r.routine_schema",
+		"r.routine_schema, r.routine_name",
+		schemas,
+	)
+}
+
+// GetGrants reads schema, table and column privileges from
+// information_schema. Routine privileges live only in mysql.procs_priv, which
+// ordinary users usually cannot read, so they are left out. MariaDB does not
+// record a grantor.
+func (a *MariaDBAnalyzer) GetGrants(ctx context.Context, schemas []string) ([]models.Grant, error) {
+	return querySchemaObjects(ctx, a.conn.queryer(), a.buildGrantQuery(schemas), schemas,
+		func() models.Grant { return models.Grant{} },
+		func(g *models.Grant) []interface{} {
+			return []interface{}{
+				&g.ObjectType, &g.Schema, &g.Object, &g.Column, &g.Grantee, &g.Grantor,
+				&g.Privilege, &g.IsGrantable, &g.IsSystemRole,
+			}
+		},
+		"grants")
+}
+
+func (a *MariaDBAnalyzer) buildGrantQuery(schemas []string) string {
+	return a.buildSchemaFilterQuery(
+		`SELECT g.object_type, g.schema_name, g.object_name, g.column_name, g.grantee, '' AS grantor, 
+		        g.privilege_type, g.is_grantable = 'YES' AS is_grantable, 
+		        REPLACE(SUBSTRING_INDEX(g.grantee, '@', 1), '''', '') 
+		            IN ('root', 'mariadb.sys', 'mysql.sys', 'mysql.session', 'mysql.infoschema') AS is_system_role 
+		 FROM ( 
+		     SELECT 'schema' AS object_type, table_schema AS schema_name, table_schema AS object_name, '' AS column_name, 
+		            grantee, privilege_type, is_grantable 
+		     FROM information_schema.schema_privileges 
+		     UNION ALL 
+		     SELECT 'table', table_schema, table_name, '', grantee, privilege_type, is_grantable 
+		     FROM information_schema.table_privileges 
+		     UNION ALL 
+		     SELECT 'column', table_schema, table_name, column_name, grantee, privilege_type, is_grantable 
+		     FROM information_schema.column_privileges 
+		 ) g 
+		 WHERE true`,
+		"g.schema_name",
+		"g.schema_name, g.object_type <> 'schema', g.object_name, g.column_name, g.grantee, g.privilege_type",
+		schemas,
+	)
+}
+
 func (a *MariaDBAnalyzer) GetSequences(_ context.Context, _ []string) ([]models.Sequence, error) {
 	// MariaDB doesn't have sequences like PostgreSQL
 	// Return empty slice
@@ -356,3 +1132,45 @@ func (a *MariaDBAnalyzer) buildSchemaFilterQuery(baseQuery, schemaColumn, orderB
 
 	return baseQuery + whereClause + fmt.Sprintf(" ORDER BY %s", orderBy)
 }
+
+func (a *MariaDBAnalyzer) GetEventTriggers(_ context.Context) ([]models.EventTrigger, error) {
+	// MariaDB doesn't have event triggers on DDL
+	// Return empty slice
+	return []models.EventTrigger{}, nil
+}
+
+func (a *MariaDBAnalyzer) GetRules(_ context.Context, _ []string) ([]models.Rule, error) {
+	// MariaDB doesn't have rewrite rules
+	// Return empty slice
+	return []models.Rule{}, nil
+}
+
+func (a *MariaDBAnalyzer) GetPublications(_ context.Context) ([]models.Publication, error) {
+	// MariaDB replicates through the binary log rather than publications
+	// Return empty slice
+	return []models.Publication{}, nil
+}
+
+func (a *MariaDBAnalyzer) GetForeignDataWrappers(_ context.Context) ([]models.ForeignDataWrapper, error) {
+	// MariaDB doesn't have foreign data wrappers
+	// Return empty slice
+	return []models.ForeignDataWrapper{}, nil
+}
+
+func (a *MariaDBAnalyzer) GetForeignServers(_ context.Context) ([]models.ForeignServer, error) {
+	// MariaDB federates through storage engines rather than foreign servers
+	// Return empty slice
+	return []models.ForeignServer{}, nil
+}
+
+func (a *MariaDBAnalyzer) GetForeignTables(_ context.Context, _ []string) ([]models.ForeignTable, error) {
+	// MariaDB doesn't have foreign tables
+	// Return empty slice
+	return []models.ForeignTable{}, nil
+}
+
+func (a *MariaDBAnalyzer) GetForeignTableColumns(_ context.Context, _ *models.ForeignTable) ([]models.Column, error) {
+	// MariaDB doesn't have foreign tables
+	// Return empty slice
+	return []models.Column{}, nil
+}
@@ -5,7 +5,9 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/lib/pq"
 	"github.com/orchard9/pg-goer/pkg/models"
 )
 
@@ -23,17 +25,48 @@ func NewSchemaAnalyzer(conn *Connection) *SchemaAnalyzer {
 func (a *PostgreSQLAnalyzer) GetTables(ctx context.Context, schemas []string) ([]models.Table, error) {
 	query := a.buildTableQuery(schemas)
 
-	return querySchemaObjects(ctx, a.conn.db, query, schemas, func() models.Table { return models.Table{} },
-		func(item *models.Table) []interface{} { return []interface{}{&item.Schema, &item.Name} },
+	return querySchemaObjects(ctx, a.conn.queryer(), query, schemas, func() models.Table { return models.Table{} },
+		func(item *models.Table) []interface{} {
+			return []interface{}{
+				&item.Schema, &item.Name, &item.Description,
+				&item.PartitionStrategy, &item.PartitionKey, &item.PartitionOf, &item.PartitionBound,
+				&item.RowSecurity, &item.ForceRowSecurity,
+				&item.HeapSizeBytes, &item.ToastSizeBytes, &item.IndexesSizeBytes, &item.TotalSizeBytes,
+			}
+		},
 		"tables")
 }
 
+// buildTableQuery lists ordinary and partitioned tables. For partitioned
+// parents it decodes the strategy and key from pg_partitioned_table; for
+// partitions it resolves the parent through pg_inherits and the bound from
+// relpartbound.
 func (a *PostgreSQLAnalyzer) buildTableQuery(schemas []string) string {
 	return a.buildSchemaFilterQuery(
-		`SELECT n.nspname AS schema_name, c.relname AS table_name 
+		`SELECT n.nspname AS schema_name, c.relname AS table_name, 
+		        COALESCE(pg_catalog.obj_description(c.oid, 'pg_class'), '') AS description, 
+		        CASE pt.partstrat 
+		            WHEN 'r' THEN 'RANGE' 
+		            WHEN 'l' THEN 'LIST' 
+		            WHEN 'h' THEN 'HASH' 
+		            ELSE '' 
+		        END AS partition_strategy, 
+		        COALESCE(substring(pg_catalog.pg_get_partkeydef(c.oid) from '^\w+ \((.*)\)$'), '') AS partition_key, 
+		        COALESCE(pn.nspname || '.' || p.relname, '') AS partition_of, 
+		        COALESCE(pg_catalog.pg_get_expr(c.relpartbound, c.oid), '') AS partition_bound, 
+		        c.relrowsecurity AS row_security, 
+		        c.relforcerowsecurity AS force_row_security, 
+		        pg_catalog.pg_relation_size(c.oid) AS heap_size_bytes, 
+		        COALESCE(pg_catalog.pg_total_relation_size(NULLIF(c.reltoastrelid, 0)), 0) AS toast_size_bytes, 
+		        pg_catalog.pg_indexes_size(c.oid) AS indexes_size_bytes, 
+		        pg_catalog.pg_total_relation_size(c.oid) AS total_size_bytes 
 		 FROM pg_catalog.pg_class c 
 		 INNER JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace 
-		 WHERE c.relkind = 'r'`,
+		 LEFT JOIN pg_catalog.pg_partitioned_table pt ON pt.partrelid = c.oid 
+		 LEFT JOIN pg_catalog.pg_inherits i ON i.inhrelid = c.oid AND c.relispartition 
+		 LEFT JOIN pg_catalog.pg_class p ON p.oid = i.inhparent 
+		 LEFT JOIN pg_catalog.pg_namespace pn ON pn.oid = p.relnamespace 
+		 WHERE c.relkind IN ('r', 'p')`,
 		"n.nspname",
 		"n.nspname, c.relname",
 		schemas,
@@ -41,66 +74,136 @@ func (a *PostgreSQLAnalyzer) buildTableQuery(schemas []string) string {
 }
 
 func (a *PostgreSQLAnalyzer) GetColumns(ctx context.Context, table *models.Table) ([]models.Column, error) {
+	key := table.Schema + "." + table.Name
+
+	columns, err := a.queryColumns(ctx, []string{key})
+	if err != nil {
+		return nil, err
+	}
+
+	return columns[key], nil
+}
+
+// GetColumnsForTables fetches the columns of every listed table in one query.
+func (a *PostgreSQLAnalyzer) GetColumnsForTables(ctx context.Context, tables []models.Table) (map[string][]models.Column, error) {
+	return a.queryColumns(ctx, qualifiedNames(tables))
+}
+
+// queryColumns returns the columns of the named relations, keyed by
+// "schema.name".
+func (a *PostgreSQLAnalyzer) queryColumns(ctx context.Context, relations []string) (map[string][]models.Column, error) {
+	columns := make(map[string][]models.Column)
+
+	if len(relations) == 0 {
+		return columns, nil
+	}
+
 	query := `
 		SELECT 
+			c.table_schema || '.' || c.table_name AS qualified_name,
 			c.column_name,
-			c.data_type,
+			CASE
+				WHEN c.domain_name IS NOT NULL THEN c.domain_schema || '.' || c.domain_name
+				WHEN c.data_type = 'USER-DEFINED' THEN c.udt_schema || '.' || c.udt_name
+				WHEN c.data_type = 'ARRAY' AND et.typtype IN ('e', 'c', 'd', 'r') THEN en.nspname || '.' || et.typname || '[]'
+				WHEN c.data_type = 'ARRAY' AND a.attnum IS NOT NULL THEN pg_catalog.format_type(a.atttypid, a.atttypmod)
+				WHEN c.data_type = 'ARRAY' AND et.oid IS NOT NULL THEN pg_catalog.format_type(et.oid, NULL) || '[]'
+				ELSE c.data_type
+			END AS data_type,
+			CASE
+				WHEN c.domain_name IS NOT NULL THEN c.domain_schema || '.' || c.domain_name
+				WHEN c.data_type = 'USER-DEFINED' THEN c.udt_schema || '.' || c.udt_name
+				WHEN c.data_type = 'ARRAY' AND et.typtype IN ('e', 'c', 'd', 'r') THEN en.nspname || '.' || et.typname
+				ELSE ''
+			END AS type_ref,
 			c.is_nullable,
 			c.column_default,
 			c.character_maximum_length,
+			CASE
+				WHEN c.data_type = 'numeric' THEN c.numeric_precision
+				WHEN c.data_type LIKE 'time%' AND a.atttypmod >= 0 THEN a.atttypmod
+			END AS precision,
+			CASE WHEN c.data_type = 'numeric' THEN c.numeric_scale END AS scale,
+			COALESCE(a.attndims, 0) AS array_dimensions,
+			COALESCE(c.collation_name, '') AS collation,
+			CASE WHEN c.is_identity = 'YES' THEN c.identity_generation ELSE '' END AS identity,
+			CASE a.attgenerated WHEN 's' THEN 'STORED' WHEN 'v' THEN 'VIRTUAL' ELSE '' END AS generated,
+			COALESCE(c.generation_expression, '') AS generation_expression,
 			COALESCE(
-				(SELECT true 
-				 FROM information_schema.table_constraints tc
-				 JOIN information_schema.key_column_usage kcu 
-				   ON tc.constraint_name = kcu.constraint_name 
-				   AND tc.table_schema = kcu.table_schema
-				 WHERE tc.constraint_type = 'PRIMARY KEY' 
-				   AND tc.table_schema = c.table_schema 
-				   AND tc.table_name = c.table_name 
-				   AND kcu.column_name = c.column_name
-				), false
-			) AS is_primary_key,
-			EXISTS (
-				SELECT 1
-				FROM information_schema.table_constraints tc
-				JOIN information_schema.key_column_usage kcu 
-				  ON tc.constraint_name = kcu.constraint_name 
-				  AND tc.table_schema = kcu.table_schema
-				WHERE tc.constraint_type = 'UNIQUE' 
-				  AND tc.table_schema = c.table_schema 
-				  AND tc.table_name = c.table_name 
-				  AND kcu.column_name = c.column_name
-			) AS is_unique
+				pg_catalog.col_description(
+					(quote_ident(c.table_schema) || '.' || quote_ident(c.table_name))::regclass,
+					c.ordinal_position::int
+				), ''
+			) AS description,
+			COALESCE(k.is_primary_key, false) AS is_primary_key,
+			COALESCE(k.is_unique, false) AS is_unique
 		FROM 
 			information_schema.columns c
+		LEFT JOIN 
+			pg_catalog.pg_attribute a 
+			  ON a.attrelid = (quote_ident(c.table_schema) || '.' || quote_ident(c.table_name))::regclass 
+			  AND a.attname = c.column_name
+		LEFT JOIN (
+			SELECT 
+				con.conrelid,
+				ck.attnum,
+				bool_or(con.contype = 'p') AS is_primary_key,
+				bool_or(con.contype = 'u') AS is_unique
+			FROM 
+				pg_catalog.pg_constraint con
+			CROSS JOIN LATERAL 
+				unnest(con.conkey) AS ck(attnum)
+			WHERE 
+				con.contype IN ('p', 'u')
+			GROUP BY 
+				con.conrelid, ck.attnum
+		) k ON k.conrelid = a.attrelid AND k.attnum = a.attnum
+		LEFT JOIN 
+			pg_catalog.pg_namespace un ON un.nspname = c.udt_schema
+		LEFT JOIN 
+			pg_catalog.pg_type ut ON ut.typname = c.udt_name AND ut.typnamespace = un.oid
+		LEFT JOIN 
+			pg_catalog.pg_type et ON et.oid = ut.typelem AND c.data_type = 'ARRAY'
+		LEFT JOIN 
+			pg_catalog.pg_namespace en ON en.oid = et.typnamespace
 		WHERE 
-			c.table_schema = $1
-			AND c.table_name = $2
+			c.table_schema || '.' || c.table_name = ANY($1)
 		ORDER BY 
-			c.ordinal_position`
+			c.table_schema, c.table_name, c.ordinal_position`
 
-	rows, err := a.conn.db.QueryContext(ctx, query, table.Schema, table.Name)
+	rows, err := a.conn.queryer().QueryContext(ctx, query, pq.Array(relations))
 	if err != nil {
 		return nil, fmt.Errorf("failed to query columns: %w", err)
 	}
 	defer rows.Close()
 
-	var columns []models.Column
-
 	for rows.Next() {
 		var (
-			col          models.Column
-			isNullable   string
-			defaultValue sql.NullString
-			maxLength    sql.NullInt64
+			qualifiedName string
+			col           models.Column
+			isNullable    string
+			defaultValue  sql.NullString
+			maxLength     sql.NullInt64
+			precision     sql.NullInt64
+			scale         sql.NullInt64
 		)
 
 		if err := rows.Scan(
+			&qualifiedName,
 			&col.Name,
 			&col.DataType,
+			&col.TypeRef,
 			&isNullable,
 			&defaultValue,
 			&maxLength,
+			&precision,
+			&scale,
+			&col.ArrayDimensions,
+			&col.Collation,
+			&col.Identity,
+			&col.Generated,
+			&col.GenerationExpression,
+			&col.Description,
 			&col.IsPrimaryKey,
 			&col.IsUnique,
 		); err != nil {
@@ -118,7 +221,10 @@ func (a *PostgreSQLAnalyzer) GetColumns(ctx context.Context, table *models.Table
 			col.MaxLength = &length
 		}
 
-		columns = append(columns, col)
+		col.Precision = nullIntPtr(precision)
+		col.Scale = nullIntPtr(scale)
+
+		columns[qualifiedName] = append(columns[qualifiedName], col)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -129,66 +235,106 @@ func (a *PostgreSQLAnalyzer) GetColumns(ctx context.Context, table *models.Table
 }
 
 func (a *PostgreSQLAnalyzer) GetForeignKeys(ctx context.Context, table *models.Table) ([]models.ForeignKey, error) {
+	key := table.Schema + "." + table.Name
+
+	foreignKeys, err := a.queryForeignKeys(ctx, []string{key})
+	if err != nil {
+		return nil, err
+	}
+
+	return foreignKeys[key], nil
+}
+
+// GetForeignKeysForTables fetches the foreign keys of every listed table in
+// one query.
+func (a *PostgreSQLAnalyzer) GetForeignKeysForTables(ctx context.Context, tables []models.Table) (map[string][]models.ForeignKey, error) {
+	return a.queryForeignKeys(ctx, qualifiedNames(tables))
+}
+
+// queryForeignKeys returns the foreign keys of the named tables, keyed by
+// "schema.name".
+func (a *PostgreSQLAnalyzer) queryForeignKeys(ctx context.Context, relations []string) (map[string][]models.ForeignKey, error) {
+	foreignKeys := make(map[string][]models.ForeignKey)
+
+	if len(relations) == 0 {
+		return foreignKeys, nil
+	}
+
+	// conkey/confkey hold the column pairs in constraint order, which keeps
+	// composite keys together instead of cross-joining their columns.
+	// Constraints cloned onto partitions (conparentid <> 0) are skipped.
 	query := `
 		SELECT 
-			tc.constraint_name,
-			kcu.column_name,
-			ccu.table_schema AS foreign_table_schema,
-			ccu.table_name AS foreign_table_name,
-			ccu.column_name AS foreign_column_name,
-			rc.delete_rule,
-			rc.update_rule
+			n.nspname || '.' || c.relname AS qualified_name,
+			c.relname AS table_name,
+			con.conname AS constraint_name,
+			ARRAY(
+				SELECT a.attname
+				FROM unnest(con.conkey) WITH ORDINALITY AS k(attnum, ord)
+				JOIN pg_catalog.pg_attribute a 
+				  ON a.attrelid = con.conrelid 
+				  AND a.attnum = k.attnum
+				ORDER BY k.ord
+			) AS source_columns,
+			fn.nspname AS foreign_table_schema,
+			fc.relname AS foreign_table_name,
+			ARRAY(
+				SELECT a.attname
+				FROM unnest(con.confkey) WITH ORDINALITY AS k(attnum, ord)
+				JOIN pg_catalog.pg_attribute a 
+				  ON a.attrelid = con.confrelid 
+				  AND a.attnum = k.attnum
+				ORDER BY k.ord
+			) AS foreign_columns,
+			` + referentialActionSQL("con.confdeltype") + ` AS delete_rule,
+			` + referentialActionSQL("con.confupdtype") + ` AS update_rule
 		FROM 
-			information_schema.table_constraints AS tc 
+			pg_catalog.pg_constraint con
+		JOIN 
+			pg_catalog.pg_class c ON c.oid = con.conrelid
 		JOIN 
-			information_schema.key_column_usage AS kcu
-			ON tc.constraint_name = kcu.constraint_name
-			AND tc.table_schema = kcu.table_schema
+			pg_catalog.pg_namespace n ON n.oid = c.relnamespace
 		JOIN 
-			information_schema.constraint_column_usage AS ccu
-			ON ccu.constraint_name = tc.constraint_name
-			AND ccu.table_schema = tc.table_schema
+			pg_catalog.pg_class fc ON fc.oid = con.confrelid
 		JOIN 
-			information_schema.referential_constraints AS rc
-			ON tc.constraint_name = rc.constraint_name
-			AND tc.table_schema = rc.constraint_schema
+			pg_catalog.pg_namespace fn ON fn.oid = fc.relnamespace
 		WHERE 
-			tc.constraint_type = 'FOREIGN KEY' 
-			AND tc.table_schema = $1
-			AND tc.table_name = $2
+			con.contype = 'f'
+			AND con.conparentid = 0
+			AND n.nspname || '.' || c.relname = ANY($1)
 		ORDER BY 
-			tc.constraint_name, kcu.ordinal_position`
+			n.nspname, c.relname, con.conname`
 
-	rows, err := a.conn.db.QueryContext(ctx, query, table.Schema, table.Name)
+	rows, err := a.conn.queryer().QueryContext(ctx, query, pq.Array(relations))
 	if err != nil {
 		return nil, fmt.Errorf("failed to query foreign keys: %w", err)
 	}
 	defer rows.Close()
 
-	var foreignKeys []models.ForeignKey
-
 	for rows.Next() {
 		var (
+			qualifiedName string
 			fk            models.ForeignKey
 			foreignSchema string
 		)
 
 		if err := rows.Scan(
+			&qualifiedName,
+			&fk.SourceTable,
 			&fk.Name,
-			&fk.SourceColumn,
+			pq.Array(&fk.SourceColumns),
 			&foreignSchema,
 			&fk.ReferencedTable,
-			&fk.ReferencedColumn,
+			pq.Array(&fk.ReferencedColumns),
 			&fk.OnDelete,
 			&fk.OnUpdate,
 		); err != nil {
 			return nil, fmt.Errorf("failed to scan foreign key row: %w", err)
 		}
 
-		fk.SourceTable = table.Name
 		fk.ReferencedTable = fmt.Sprintf("%s.%s", foreignSchema, fk.ReferencedTable)
 
-		foreignKeys = append(foreignKeys, fk)
+		foreignKeys[qualifiedName] = append(foreignKeys[qualifiedName], fk)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -198,64 +344,491 @@ func (a *PostgreSQLAnalyzer) GetForeignKeys(ctx context.Context, table *models.T
 	return foreignKeys, nil
 }
 
-func (a *PostgreSQLAnalyzer) GetTableRowCounts(ctx context.Context, tables []models.Table) (map[string]int64, error) {
-	if len(tables) == 0 {
-		return make(map[string]int64), nil
+// referentialActionSQL decodes a pg_constraint action code into the rule
+// names used by information_schema.referential_constraints.
+func referentialActionSQL(column string) string {
+	return fmt.Sprintf(`CASE %s
+				WHEN 'a' THEN 'NO ACTION'
+				WHEN 'r' THEN 'RESTRICT'
+				WHEN 'c' THEN 'CASCADE'
+				WHEN 'n' THEN 'SET NULL'
+				WHEN 'd' THEN 'SET DEFAULT'
+			END`, column)
+}
+
+func (a *PostgreSQLAnalyzer) GetConstraints(ctx context.Context, table *models.Table) ([]models.Constraint, error) {
+	key := table.Schema + "." + table.Name
+
+	constraints, err := a.queryConstraints(ctx, []string{key})
+	if err != nil {
+		return nil, err
+	}
+
+	return constraints[key], nil
+}
+
+// GetConstraintsForTables fetches the constraints of every listed table in
+// one query.
+func (a *PostgreSQLAnalyzer) GetConstraintsForTables(ctx context.Context, tables []models.Table) (map[string][]models.Constraint, error) {
+	return a.queryConstraints(ctx, qualifiedNames(tables))
+}
+
+// queryConstraints returns the constraints of the named tables, keyed by
+// "schema.name".
+func (a *PostgreSQLAnalyzer) queryConstraints(ctx context.Context, relations []string) (map[string][]models.Constraint, error) {
+	constraints := make(map[string][]models.Constraint)
+
+	if len(relations) == 0 {
+		return constraints, nil
+	}
+
+	query := `
+		SELECT 
+			n.nspname || '.' || c.relname AS qualified_name,
+			con.conname AS constraint_name,
+			CASE con.contype
+				WHEN 'p' THEN 'PRIMARY KEY'
+				WHEN 'u' THEN 'UNIQUE'
+				WHEN 'f' THEN 'FOREIGN KEY'
+				WHEN 'c' THEN 'CHECK'
+				WHEN 'x' THEN 'EXCLUDE'
+				WHEN 't' THEN 'TRIGGER'
+				ELSE con.contype::text
+			END AS constraint_type,
+			ARRAY(
+				SELECT a.attname
+				FROM unnest(con.conkey) WITH ORDINALITY AS k(attnum, ord)
+				JOIN pg_catalog.pg_attribute a 
+				  ON a.attrelid = con.conrelid 
+				  AND a.attnum = k.attnum
+				ORDER BY k.ord
+			) AS columns,
+			pg_catalog.pg_get_constraintdef(con.oid, true) AS definition,
+			con.condeferrable AS is_deferrable,
+			con.condeferred AS is_deferred,
+			con.convalidated AS is_validated
+		FROM 
+			pg_catalog.pg_constraint con
+		JOIN 
+			pg_catalog.pg_class c ON c.oid = con.conrelid
+		JOIN 
+			pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+		WHERE 
+			n.nspname || '.' || c.relname = ANY($1)
+			AND con.conparentid = 0
+		ORDER BY 
+			n.nspname, 
+			c.relname, 
+			CASE con.contype WHEN 'p' THEN 0 WHEN 'u' THEN 1 WHEN 'f' THEN 2 ELSE 3 END, 
+			con.conname`
+
+	rows, err := a.conn.queryer().QueryContext(ctx, query, pq.Array(relations))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query constraints: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			qualifiedName string
+			constraint    models.Constraint
+		)
+
+		if err := rows.Scan(
+			&qualifiedName,
+			&constraint.Name,
+			&constraint.Type,
+			pq.Array(&constraint.Columns),
+			&constraint.Definition,
+			&constraint.IsDeferrable,
+			&constraint.IsDeferred,
+			&constraint.IsValidated,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan constraint row: %w", err)
+		}
+
+		constraints[qualifiedName] = append(constraints[qualifiedName], constraint)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating constraint rows: %w", err)
+	}
+
+	return constraints, nil
+}
+
+func (a *PostgreSQLAnalyzer) GetPolicies(ctx context.Context, table *models.Table) ([]models.Policy, error) {
+	key := table.Schema + "." + table.Name
+
+	policies, err := a.queryPolicies(ctx, []string{key})
+	if err != nil {
+		return nil, err
+	}
+
+	return policies[key], nil
+}
+
+// GetPoliciesForTables fetches the row-level security policies of every
+// listed table in one query.
+func (a *PostgreSQLAnalyzer) GetPoliciesForTables(ctx context.Context, tables []models.Table) (map[string][]models.Policy, error) {
+	return a.queryPolicies(ctx, qualifiedNames(tables))
+}
+
+// queryPolicies returns the policies of the named tables, keyed by
+// "schema.name".
+func (a *PostgreSQLAnalyzer) queryPolicies(ctx context.Context, relations []string) (map[string][]models.Policy, error) {
+	policies := make(map[string][]models.Policy)
+
+	if len(relations) == 0 {
+		return policies, nil
+	}
+
+	query := `
+		SELECT 
+			p.schemaname || '.' || p.tablename AS qualified_name,
+			p.policyname,
+			p.cmd,
+			p.permissive = 'PERMISSIVE' AS is_permissive,
+			p.roles,
+			COALESCE(p.qual, '') AS using_expression,
+			COALESCE(p.with_check, '') AS with_check_expression
+		FROM 
+			pg_catalog.pg_policies p
+		WHERE 
+			p.schemaname || '.' || p.tablename = ANY($1)
+		ORDER BY 
+			p.schemaname, p.tablename, p.policyname`
+
+	rows, err := a.conn.queryer().QueryContext(ctx, query, pq.Array(relations))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query policies: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			qualifiedName string
+			policy        models.Policy
+		)
+
+		if err := rows.Scan(
+			&qualifiedName,
+			&policy.Name,
+			&policy.Command,
+			&policy.IsPermissive,
+			pq.Array(&policy.Roles),
+			&policy.Using,
+			&policy.WithCheck,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan policy row: %w", err)
+		}
+
+		policies[qualifiedName] = append(policies[qualifiedName], policy)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating policy rows: %w", err)
+	}
+
+	return policies, nil
+}
+
+// GetTableRowCounts starts from the planner estimate in pg_class.reltuples
+// and, for the exact and sample methods, replaces it with a COUNT(*) over the
+// whole table or over a TABLESAMPLE. A count that runs past opts.Timeout, or
+// on a table the role cannot read, keeps the estimate. Partitioned parents
+// hold no rows of their own and are not counted.
+func (a *PostgreSQLAnalyzer) GetTableRowCounts(ctx context.Context, tables []models.Table, opts RowCountOptions) (map[string]models.RowCount, error) {
+	rowCounts := make(map[string]models.RowCount)
+
+	if len(tables) == 0 || opts.Method == models.RowCountNone {
+		return rowCounts, nil
 	}
 
-	// Build table name list for query
-	tableNames := make([]string, 0, len(tables))
-	tableMap := make(map[string]string) // qualified name -> simple name
+	estimates, err := a.estimateRowCounts(ctx, tables)
+	if err != nil {
+		return nil, err
+	}
 
 	for i := range tables {
 		table := &tables[i]
-		qualifiedName := fmt.Sprintf("%s.%s", table.Schema, table.Name)
-		tableNames = append(tableNames, qualifiedName)
-		tableMap[qualifiedName] = table.Name
+
+		key := table.Schema + "." + table.Name
+
+		estimate, exists := estimates[key]
+		if !exists {
+			continue
+		}
+
+		rowCounts[key] = estimate.rowCount
+
+		if opts.Method == models.RowCountEstimate || table.PartitionStrategy != "" {
+			continue
+		}
+
+		rowCount, err := a.countRows(ctx, table, opts, estimate.pages)
+		if err != nil {
+			if IsTimeout(err) || IsPermissionDenied(err) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		rowCounts[key] = rowCount
+	}
+
+	return rowCounts, nil
+}
+
+// sampleMinPages is the size below which a table is counted exactly even in
+// sample mode: scanning 1000 pages is cheap and a 1% sample of them is noisy.
+const sampleMinPages = 1000
+
+type pgRowEstimate struct {
+	rowCount models.RowCount
+	pages    int64
+}
+
+// estimateRowCounts reads reltuples and relpages, keyed by qualified name. An
+// estimate is only as good as the last ANALYZE, so tables that were never
+// analyzed get low confidence.
+func (a *PostgreSQLAnalyzer) estimateRowCounts(ctx context.Context, tables []models.Table) (map[string]pgRowEstimate, error) {
+	tableNames := make([]string, len(tables))
+	for i := range tables {
+		tableNames[i] = tables[i].Schema + "." + tables[i].Name
 	}
 
 	query := `
 		SELECT 
-			schemaname || '.' || relname AS qualified_name,
-			COALESCE(n_tup_ins - n_tup_del, 0) AS row_count
+			n.nspname || '.' || c.relname AS qualified_name,
+			GREATEST(c.reltuples, 0)::bigint AS row_estimate,
+			c.relpages::bigint AS pages,
+			COALESCE(s.last_analyze, s.last_autoanalyze) IS NOT NULL AS analyzed
 		FROM 
-			pg_stat_user_tables 
+			pg_catalog.pg_class c
+		JOIN 
+			pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+		LEFT JOIN 
+			pg_catalog.pg_stat_user_tables s ON s.relid = c.oid
 		WHERE 
-			schemaname || '.' || relname = ANY($1)`
+			n.nspname || '.' || c.relname = ANY($1)
+			AND c.relkind IN ('r', 'p')`
 
-	rows, err := a.conn.db.QueryContext(ctx, query, "{"+strings.Join(tableNames, ",")+"}")
+	rows, err := a.conn.queryer().QueryContext(ctx, query, pq.Array(tableNames))
 	if err != nil {
 		return nil, fmt.Errorf("failed to query table row counts: %w", err)
 	}
 	defer rows.Close()
 
-	rowCounts := make(map[string]int64)
+	estimates := make(map[string]pgRowEstimate)
 
 	for rows.Next() {
 		var (
 			qualifiedName string
-			rowCount      int64
+			estimate      pgRowEstimate
+			analyzed      bool
 		)
 
-		if err := rows.Scan(&qualifiedName, &rowCount); err != nil {
+		if err := rows.Scan(&qualifiedName, &estimate.rowCount.Count, &estimate.pages, &analyzed); err != nil {
 			return nil, fmt.Errorf("failed to scan row count row: %w", err)
 		}
 
-		if simpleName, exists := tableMap[qualifiedName]; exists {
-			rowCounts[simpleName] = rowCount
+		estimate.rowCount.Method = models.RowCountEstimate
+		estimate.rowCount.Confidence = models.ConfidenceLow
+
+		if analyzed {
+			estimate.rowCount.Confidence = models.ConfidenceMedium
 		}
+
+		estimates[qualifiedName] = estimate
 	}
 
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("error iterating row count rows: %w", err)
 	}
 
-	return rowCounts, nil
+	return estimates, nil
+}
+
+// GetTableStats reads scan, write and vacuum statistics from
+// pg_stat_user_tables and buffer cache hits from pg_statio_user_tables.
+func (a *PostgreSQLAnalyzer) GetTableStats(ctx context.Context, tables []models.Table) (map[string]models.TableStats, error) {
+	stats := make(map[string]models.TableStats)
+
+	if len(tables) == 0 {
+		return stats, nil
+	}
+
+	tableNames := make([]string, len(tables))
+	for i := range tables {
+		tableNames[i] = tables[i].Schema + "." + tables[i].Name
+	}
+
+	query := `
+		SELECT 
+			s.schemaname || '.' || s.relname AS qualified_name,
+			COALESCE(s.seq_scan, 0),
+			COALESCE(s.idx_scan, 0),
+			s.n_tup_ins,
+			s.n_tup_upd,
+			s.n_tup_del,
+			s.n_live_tup,
+			s.n_dead_tup,
+			s.last_vacuum,
+			s.last_autovacuum,
+			s.last_analyze,
+			s.last_autoanalyze,
+			COALESCE(io.heap_blks_hit, 0),
+			COALESCE(io.heap_blks_read, 0),
+			COALESCE(io.idx_blks_hit, 0),
+			COALESCE(io.idx_blks_read, 0)
+		FROM 
+			pg_catalog.pg_stat_user_tables s
+		LEFT JOIN 
+			pg_catalog.pg_statio_user_tables io ON io.relid = s.relid
+		WHERE 
+			s.schemaname || '.' || s.relname = ANY($1)`
+
+	rows, err := a.conn.queryer().QueryContext(ctx, query, pq.Array(tableNames))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query table statistics: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			qualifiedName   string
+			stat            models.TableStats
+			lastVacuum      sql.NullTime
+			lastAutovacuum  sql.NullTime
+			lastAnalyze     sql.NullTime
+			lastAutoanalyze sql.NullTime
+		)
+
+		err := rows.Scan(
+			&qualifiedName,
+			&stat.SeqScans, &stat.IndexScans,
+			&stat.TuplesInserted, &stat.TuplesUpdated, &stat.TuplesDeleted,
+			&stat.LiveTuples, &stat.DeadTuples,
+			&lastVacuum, &lastAutovacuum, &lastAnalyze, &lastAutoanalyze,
+			&stat.HeapBlocksHit, &stat.HeapBlocksRead, &stat.IndexBlocksHit, &stat.IndexBlocksRead,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan table statistics row: %w", err)
+		}
+
+		stat.LastVacuum = nullTimePtr(lastVacuum)
+		stat.LastAutovacuum = nullTimePtr(lastAutovacuum)
+		stat.LastAnalyze = nullTimePtr(lastAnalyze)
+		stat.LastAutoanalyze = nullTimePtr(lastAutoanalyze)
+
+		stats[qualifiedName] = stat
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating table statistics rows: %w", err)
+	}
+
+	return stats, nil
+}
+
+// nullIntPtr converts a nullable integer to a pointer that is nil for NULL.
+func nullIntPtr(n sql.NullInt64) *int {
+	if !n.Valid {
+		return nil
+	}
+
+	value := int(n.Int64)
+
+	return &value
+}
+
+// nullTimePtr converts a nullable timestamp to a pointer that is nil for NULL.
+func nullTimePtr(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+
+	return &t.Time
+}
+
+// countRows counts a table exactly, or for large tables in sample mode scales
+// up a count over 1% of its pages. The statement timeout is set locally in a
+// read-only transaction so it cannot leak onto other pooled connections.
+// Inside a snapshot it is scoped by a savepoint instead, which is rolled back
+// afterwards so that a cancelled count does not abort the snapshot.
+func (a *PostgreSQLAnalyzer) countRows(ctx context.Context, table *models.Table, opts RowCountOptions, pages int64) (models.RowCount, error) {
+	relation := pq.QuoteIdentifier(table.Schema) + "." + pq.QuoteIdentifier(table.Name)
+	rowCount := models.RowCount{Method: models.RowCountExact, Confidence: models.ConfidenceHigh}
+	query := "SELECT count(*) FROM " + relation
+
+	if opts.Method == models.RowCountSample && pages >= sampleMinPages {
+		rowCount = models.RowCount{Method: models.RowCountSample, Confidence: models.ConfidenceMedium}
+		query = "SELECT count(*) * 100 FROM " + relation + " TABLESAMPLE SYSTEM (1)"
+	}
+
+	tx := a.conn.tx
+
+	if tx == nil {
+		var err error
+
+		tx, err = a.conn.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+		if err != nil {
+			return rowCount, fmt.Errorf("failed to begin row count transaction: %w", err)
+		}
+		defer func() { _ = tx.Rollback() }()
+	} else {
+		if _, err := tx.ExecContext(ctx, "SAVEPOINT row_count"); err != nil {
+			return rowCount, fmt.Errorf("failed to set row count savepoint: %w", err)
+		}
+		defer func() { _, _ = tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT row_count; RELEASE SAVEPOINT row_count") }()
+	}
+
+	if opts.Timeout > 0 {
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf("SET LOCAL statement_timeout = %d", opts.Timeout.Milliseconds())); err != nil {
+			return rowCount, fmt.Errorf("failed to set statement timeout: %w", err)
+		}
+	}
+
+	if err := tx.QueryRowContext(ctx, query).Scan(&rowCount.Count); err != nil {
+		return rowCount, fmt.Errorf("failed to count rows in %s.%s: %w", table.Schema, table.Name, err)
+	}
+
+	return rowCount, nil
 }
 
+// GetIndexes reads each index's key parts in index order with
+// pg_get_indexdef(oid, column, true), which yields the column name or the
+// expression text. Positions past indnkeyatts are INCLUDE columns.
 func (a *PostgreSQLAnalyzer) GetIndexes(ctx context.Context, table *models.Table) ([]models.Index, error) {
+	key := table.Schema + "." + table.Name
+
+	indexes, err := a.queryIndexes(ctx, []string{key})
+	if err != nil {
+		return nil, err
+	}
+
+	return indexes[key], nil
+}
+
+// GetIndexesForTables fetches the indexes of every listed table in one query.
+func (a *PostgreSQLAnalyzer) GetIndexesForTables(ctx context.Context, tables []models.Table) (map[string][]models.Index, error) {
+	return a.queryIndexes(ctx, qualifiedNames(tables))
+}
+
+// queryIndexes returns the indexes of the named tables and materialized
+// views, keyed by "schema.name".
+func (a *PostgreSQLAnalyzer) queryIndexes(ctx context.Context, relations []string) (map[string][]models.Index, error) {
+	indexes := make(map[string][]models.Index)
+
+	if len(relations) == 0 {
+		return indexes, nil
+	}
+
 	query := `
-		SELECT DISTINCT
+		SELECT 
+			n.nspname || '.' || t.relname AS qualified_name,
 			i.relname AS index_name,
 			CASE 
 				WHEN ic.indisprimary THEN 'PRIMARY KEY'
@@ -265,7 +838,41 @@ func (a *PostgreSQLAnalyzer) GetIndexes(ctx context.Context, table *models.Table
 			ic.indisprimary AS is_primary,
 			ic.indisunique AS is_unique,
 			am.amname AS access_method,
-			array_agg(a.attname ORDER BY ic.indkey) AS columns
+			pg_catalog.pg_get_indexdef(ic.indexrelid) AS definition,
+			ARRAY(
+				SELECT pg_catalog.pg_get_indexdef(ic.indexrelid, k, true)
+				FROM generate_series(1, ic.indnkeyatts) AS k
+				ORDER BY k
+			) AS key_parts,
+			ARRAY(
+				SELECT ic.indkey[k - 1] = 0
+				FROM generate_series(1, ic.indnkeyatts) AS k
+				ORDER BY k
+			) AS is_expression,
+			ARRAY(
+				SELECT (ic.indoption[k - 1] & 1) <> 0
+				FROM generate_series(1, ic.indnkeyatts) AS k
+				ORDER BY k
+			) AS descending,
+			ARRAY(
+				SELECT (ic.indoption[k - 1] & 2) <> 0
+				FROM generate_series(1, ic.indnkeyatts) AS k
+				ORDER BY k
+			) AS nulls_first,
+			ARRAY(
+				SELECT CASE WHEN opc.opcdefault THEN '' ELSE opc.opcname::text END
+				FROM generate_series(1, ic.indnkeyatts) AS k
+				JOIN pg_catalog.pg_opclass opc ON opc.oid = ic.indclass[k - 1]
+				ORDER BY k
+			) AS opclasses,
+			ARRAY(
+				SELECT pg_catalog.pg_get_indexdef(ic.indexrelid, k, true)
+				FROM generate_series(ic.indnkeyatts + 1, ic.indnatts) AS k
+				ORDER BY k
+			) AS include_columns,
+			COALESCE(pg_catalog.pg_get_expr(ic.indpred, ic.indrelid, true), '') AS predicate,
+			ic.indisvalid AS is_valid,
+			pg_catalog.pg_relation_size(ic.indexrelid) AS size_bytes
 		FROM 
 			pg_catalog.pg_index ic
 		JOIN 
@@ -276,50 +883,68 @@ func (a *PostgreSQLAnalyzer) GetIndexes(ctx context.Context, table *models.Table
 			pg_catalog.pg_namespace n ON n.oid = t.relnamespace
 		JOIN 
 			pg_catalog.pg_am am ON am.oid = i.relam
-		JOIN 
-			pg_catalog.pg_attribute a ON a.attrelid = t.oid 
-				AND a.attnum = ANY(ic.indkey)
 		WHERE 
-			n.nspname = $1
-			AND t.relname = $2
-			AND t.relkind = 'r'
-		GROUP BY 
-			i.relname, ic.indisprimary, ic.indisunique, am.amname
+			n.nspname || '.' || t.relname = ANY($1)
+			AND t.relkind IN ('r', 'p', 'm')
 		ORDER BY 
-			ic.indisprimary DESC, ic.indisunique DESC, i.relname`
+			n.nspname, t.relname, ic.indisprimary DESC, ic.indisunique DESC, i.relname`
 
-	rows, err := a.conn.db.QueryContext(ctx, query, table.Schema, table.Name)
+	rows, err := a.conn.queryer().QueryContext(ctx, query, pq.Array(relations))
 	if err != nil {
 		return nil, fmt.Errorf("failed to query indexes: %w", err)
 	}
 	defer rows.Close()
 
-	var indexes []models.Index
-
 	for rows.Next() {
 		var (
-			idx     models.Index
-			columns string
+			qualifiedName string
+			idx           models.Index
+			keyParts      []string
+			isExpression  []bool
+			descending    []bool
+			nullsFirst    []bool
+			opClasses     []string
 		)
 
 		if err := rows.Scan(
+			&qualifiedName,
 			&idx.Name,
 			&idx.Type,
 			&idx.IsPrimary,
 			&idx.IsUnique,
 			&idx.Method,
-			&columns,
+			&idx.Definition,
+			pq.Array(&keyParts),
+			pq.Array(&isExpression),
+			pq.Array(&descending),
+			pq.Array(&nullsFirst),
+			pq.Array(&opClasses),
+			pq.Array(&idx.Include),
+			&idx.Predicate,
+			&idx.IsValid,
+			&idx.SizeBytes,
 		); err != nil {
 			return nil, fmt.Errorf("failed to scan index row: %w", err)
 		}
 
-		// Parse the PostgreSQL array format {col1,col2}
-		columns = strings.Trim(columns, "{}")
-		if columns != "" {
-			idx.Columns = strings.Split(columns, ",")
+		for k, keyPart := range keyParts {
+			part := models.IndexKeyPart{
+				Descending: descending[k],
+				NullsFirst: nullsFirst[k],
+				OpClass:    opClasses[k],
+			}
+
+			if isExpression[k] {
+				part.Expression = keyPart
+			} else {
+				part.Column = keyPart
+			}
+
+			idx.Columns = append(idx.Columns, keyPart)
+			idx.KeyParts = append(idx.KeyParts, part)
 		}
 
-		indexes = append(indexes, idx)
+		indexes[qualifiedName] = append(indexes[qualifiedName], idx)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -330,27 +955,84 @@ func (a *PostgreSQLAnalyzer) GetIndexes(ctx context.Context, table *models.Table
 }
 
 func (a *PostgreSQLAnalyzer) GetTriggers(ctx context.Context, table *models.Table) ([]models.Trigger, error) {
+	key := table.Schema + "." + table.Name
+
+	triggers, err := a.queryTriggers(ctx, []string{key})
+	if err != nil {
+		return nil, err
+	}
+
+	return triggers[key], nil
+}
+
+// GetTriggersForTables fetches the triggers of every listed table in one query.
+func (a *PostgreSQLAnalyzer) GetTriggersForTables(ctx context.Context, tables []models.Table) (map[string][]models.Trigger, error) {
+	return a.queryTriggers(ctx, qualifiedNames(tables))
+}
+
+// GetViewTriggers returns the INSTEAD OF and statement-level triggers on a view.
+func (a *PostgreSQLAnalyzer) GetViewTriggers(ctx context.Context, view *models.View) ([]models.Trigger, error) {
+	key := view.Schema + "." + view.Name
+
+	triggers, err := a.queryTriggers(ctx, []string{key})
+	if err != nil {
+		return nil, err
+	}
+
+	return triggers[key], nil
+}
+
+// queryTriggers decodes tgtype's bit flags (1 ROW, 2 BEFORE, 4 INSERT,
+// 8 DELETE, 16 UPDATE, 32 TRUNCATE, 64 INSTEAD) and reads the WHEN condition
+// back out of pg_get_triggerdef, since tgqual refers to both OLD and NEW and
+// cannot be deparsed on its own.
+func (a *PostgreSQLAnalyzer) queryTriggers(ctx context.Context, relations []string) (map[string][]models.Trigger, error) {
+	triggers := make(map[string][]models.Trigger)
+
+	if len(relations) == 0 {
+		return triggers, nil
+	}
+
 	query := `
 		SELECT 
+			n.nspname || '.' || c.relname AS qualified_name,
 			t.tgname AS trigger_name,
-			CASE t.tgtype & cast(2 as int2)
-				WHEN 0 THEN 'AFTER'
-				ELSE 'BEFORE'
+			CASE
+				WHEN t.tgtype & 64 <> 0 THEN 'INSTEAD OF'
+				WHEN t.tgtype & 2 <> 0 THEN 'BEFORE'
+				ELSE 'AFTER'
 			END AS timing,
-			CASE t.tgtype & cast(28 as int2)
-				WHEN 4 THEN 'INSERT'
-				WHEN 8 THEN 'DELETE'
-				WHEN 16 THEN 'UPDATE'
-				WHEN 12 THEN 'INSERT,DELETE'
-				WHEN 20 THEN 'INSERT,UPDATE'
-				WHEN 24 THEN 'DELETE,UPDATE'
-				WHEN 28 THEN 'INSERT,DELETE,UPDATE'
-			END AS event,
+			concat_ws(',',
+				CASE WHEN t.tgtype & 4 <> 0 THEN 'INSERT' END,
+				CASE WHEN t.tgtype & 8 <> 0 THEN 'DELETE' END,
+				CASE WHEN t.tgtype & 16 <> 0 THEN 'UPDATE' END,
+				CASE WHEN t.tgtype & 32 <> 0 THEN 'TRUNCATE' END
+			) AS event,
 			p.proname AS function_name,
-			CASE t.tgtype & cast(1 as int2)
+			pn.nspname AS function_schema,
+			CASE t.tgtype & 1
 				WHEN 0 THEN 'STATEMENT'
 				ELSE 'ROW'
-			END AS orientation
+			END AS orientation,
+			pg_catalog.pg_get_triggerdef(t.oid, true) AS definition,
+			CASE WHEN t.tgqual IS NOT NULL THEN
+				COALESCE(substring(pg_catalog.pg_get_triggerdef(t.oid, true) FROM ' WHEN \((.*)\) EXECUTE (?:FUNCTION|PROCEDURE) '), '')
+			ELSE '' END AS condition,
+			ARRAY(
+				SELECT a.attname
+				FROM unnest(t.tgattr::pg_catalog.int2[]) WITH ORDINALITY AS k(attnum, ord)
+				JOIN pg_catalog.pg_attribute a ON a.attrelid = t.tgrelid AND a.attnum = k.attnum
+				ORDER BY k.ord
+			) AS columns,
+			COALESCE(t.tgoldtable, '') AS old_table,
+			COALESCE(t.tgnewtable, '') AS new_table,
+			t.tgconstraint <> 0 AS is_constraint,
+			CASE t.tgenabled
+				WHEN 'D' THEN 'DISABLED'
+				WHEN 'R' THEN 'REPLICA'
+				WHEN 'A' THEN 'ALWAYS'
+				ELSE 'ENABLED'
+			END AS enabled
 		FROM 
 			pg_catalog.pg_trigger t
 		JOIN 
@@ -359,35 +1041,46 @@ func (a *PostgreSQLAnalyzer) GetTriggers(ctx context.Context, table *models.Tabl
 			pg_catalog.pg_namespace n ON n.oid = c.relnamespace
 		JOIN 
 			pg_catalog.pg_proc p ON p.oid = t.tgfoid
+		JOIN 
+			pg_catalog.pg_namespace pn ON pn.oid = p.pronamespace
 		WHERE 
-			n.nspname = $1
-			AND c.relname = $2
+			n.nspname || '.' || c.relname = ANY($1)
 			AND NOT t.tgisinternal
 		ORDER BY 
-			t.tgname`
+			n.nspname, c.relname, t.tgname`
 
-	rows, err := a.conn.db.QueryContext(ctx, query, table.Schema, table.Name)
+	rows, err := a.conn.queryer().QueryContext(ctx, query, pq.Array(relations))
 	if err != nil {
 		return nil, fmt.Errorf("failed to query triggers: %w", err)
 	}
 	defer rows.Close()
 
-	var triggers []models.Trigger
-
 	for rows.Next() {
-		var trigger models.Trigger
+		var (
+			qualifiedName string
+			trigger       models.Trigger
+		)
 
 		if err := rows.Scan(
+			&qualifiedName,
 			&trigger.Name,
 			&trigger.Timing,
 			&trigger.Event,
 			&trigger.Function,
+			&trigger.FunctionSchema,
 			&trigger.Orientation,
+			&trigger.Definition,
+			&trigger.Condition,
+			pq.Array(&trigger.Columns),
+			&trigger.OldTable,
+			&trigger.NewTable,
+			&trigger.IsConstraint,
+			&trigger.Enabled,
 		); err != nil {
 			return nil, fmt.Errorf("failed to scan trigger row: %w", err)
 		}
 
-		triggers = append(triggers, trigger)
+		triggers[qualifiedName] = append(triggers[qualifiedName], trigger)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -397,35 +1090,160 @@ func (a *PostgreSQLAnalyzer) GetTriggers(ctx context.Context, table *models.Tabl
 	return triggers, nil
 }
 
-func (a *PostgreSQLAnalyzer) GetExtensions(ctx context.Context) ([]models.Extension, error) {
+func (a *PostgreSQLAnalyzer) GetDatabaseInfo(ctx context.Context, schemas []string) (*models.DatabaseInfo, error) {
 	query := `
 		SELECT 
-			e.extname AS extension_name,
-			e.extversion AS extension_version,
-			n.nspname AS schema_name
+			current_database(),
+			pg_catalog.pg_get_userbyid(d.datdba) AS owner,
+			pg_catalog.pg_encoding_to_char(d.encoding) AS encoding,
+			d.datcollate,
+			d.datctype,
+			pg_catalog.current_setting('server_version') AS server_version,
+			pg_catalog.current_setting('TimeZone') AS time_zone
 		FROM 
-			pg_catalog.pg_extension e
-		JOIN 
-			pg_catalog.pg_namespace n ON n.oid = e.extnamespace
+			pg_catalog.pg_database d
 		WHERE 
-			e.extname NOT IN ('plpgsql')  -- Exclude built-in extensions
-		ORDER BY 
-			e.extname`
+			d.datname = current_database()`
+
+	info := &models.DatabaseInfo{Engine: "PostgreSQL"}
+
+	if err := a.conn.queryer().QueryRowContext(ctx, query).Scan(
+		&info.Name,
+		&info.Owner,
+		&info.Encoding,
+		&info.Collation,
+		&info.CType,
+		&info.ServerVersion,
+		&info.TimeZone,
+	); err != nil {
+		return nil, fmt.Errorf("failed to query database info: %w", err)
+	}
 
-	rows, err := a.conn.db.QueryContext(ctx, query)
+	settings, err := a.getNonDefaultSettings(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query extensions: %w", err)
+		return nil, err
 	}
-	defer rows.Close()
-
-	var extensions []models.Extension
 
-	for rows.Next() {
-		var ext models.Extension
+	info.Settings = settings
 
-		if err := rows.Scan(&ext.Name, &ext.Version, &ext.Schema); err != nil {
-			return nil, fmt.Errorf("failed to scan extension row: %w", err)
-		}
+	schemaQuery := a.buildSchemaFilterQuery(
+		`SELECT n.nspname AS schema_name, 
+		        pg_catalog.pg_get_userbyid(n.nspowner) AS owner, 
+		        COALESCE(pg_catalog.obj_description(n.oid, 'pg_namespace'), '') AS description 
+		 FROM pg_catalog.pg_namespace n 
+		 WHERE n.nspname NOT LIKE 'pg\_temp\_%' AND n.nspname NOT LIKE 'pg\_toast\_temp\_%'`,
+		"n.nspname",
+		"n.nspname",
+		schemas,
+	)
+
+	info.Schemas, err = querySchemaObjects(ctx, a.conn.queryer(), schemaQuery, schemas, func() models.SchemaInfo { return models.SchemaInfo{} },
+		func(item *models.SchemaInfo) []interface{} {
+			return []interface{}{&item.Name, &item.Owner, &item.Description}
+		},
+		"schemas")
+	if err != nil {
+		return nil, err
+	}
+
+	return info, nil
+}
+
+// getNonDefaultSettings lists settings configured for the server or database
+// rather than by the reporting session. Memory settings are shown in readable
+// sizes instead of raw unit counts; primary_conninfo is skipped because it
+// can carry a replication password.
+func (a *PostgreSQLAnalyzer) getNonDefaultSettings(ctx context.Context) ([]models.Setting, error) {
+	query := `
+		SELECT 
+			s.name,
+			CASE
+				WHEN s.unit IN ('8kB', 'kB', 'MB') AND s.setting ~ '^[0-9]+$' THEN
+					pg_catalog.pg_size_pretty(s.setting::bigint * CASE s.unit
+						WHEN '8kB' THEN 8192
+						WHEN 'kB' THEN 1024
+						ELSE 1048576
+					END)
+				WHEN s.unit IS NOT NULL THEN s.setting || s.unit
+				ELSE COALESCE(s.setting, '')
+			END AS value,
+			s.source
+		FROM 
+			pg_catalog.pg_settings s
+		WHERE 
+			s.source NOT IN ('default', 'override', 'client', 'session')
+			AND s.name <> 'primary_conninfo'
+		ORDER BY 
+			s.name`
+
+	rows, err := a.conn.queryer().QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query settings: %w", err)
+	}
+	defer rows.Close()
+
+	var settings []models.Setting
+
+	for rows.Next() {
+		var setting models.Setting
+
+		if err := rows.Scan(&setting.Name, &setting.Value, &setting.Source); err != nil {
+			return nil, fmt.Errorf("failed to scan setting row: %w", err)
+		}
+
+		settings = append(settings, setting)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating setting rows: %w", err)
+	}
+
+	return settings, nil
+}
+
+// GetDatabaseSize returns the on-disk size of the connected database.
+func (a *PostgreSQLAnalyzer) GetDatabaseSize(ctx context.Context) (int64, error) {
+	var size int64
+
+	if err := a.conn.queryer().QueryRowContext(ctx, "SELECT pg_catalog.pg_database_size(current_database())").Scan(&size); err != nil {
+		return 0, fmt.Errorf("failed to query database size: %w", err)
+	}
+
+	return size, nil
+}
+
+func (a *PostgreSQLAnalyzer) GetExtensions(ctx context.Context) ([]models.Extension, error) {
+	query := `
+		SELECT 
+			e.extname AS extension_name,
+			e.extversion AS extension_version,
+			n.nspname AS schema_name,
+			COALESCE(ae.default_version, '') AS default_version
+		FROM 
+			pg_catalog.pg_extension e
+		JOIN 
+			pg_catalog.pg_namespace n ON n.oid = e.extnamespace
+		LEFT JOIN 
+			pg_catalog.pg_available_extensions ae ON ae.name = e.extname
+		WHERE 
+			e.extname NOT IN ('plpgsql')  -- Exclude built-in extensions
+		ORDER BY 
+			e.extname`
+
+	rows, err := a.conn.queryer().QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query extensions: %w", err)
+	}
+	defer rows.Close()
+
+	var extensions []models.Extension
+
+	for rows.Next() {
+		var ext models.Extension
+
+		if err := rows.Scan(&ext.Name, &ext.Version, &ext.Schema, &ext.DefaultVersion); err != nil {
+			return nil, fmt.Errorf("failed to scan extension row: %w", err)
+		}
 
 		extensions = append(extensions, ext)
 	}
@@ -440,20 +1258,398 @@ func (a *PostgreSQLAnalyzer) GetExtensions(ctx context.Context) ([]models.Extens
 func (a *PostgreSQLAnalyzer) GetViews(ctx context.Context, schemas []string) ([]models.View, error) {
 	query := a.buildViewQuery(schemas)
 
-	return querySchemaObjects(ctx, a.conn.db, query, schemas, func() models.View { return models.View{} },
-		func(item *models.View) []interface{} { return []interface{}{&item.Schema, &item.Name} },
+	return querySchemaObjects(ctx, a.conn.queryer(), query, schemas, func() models.View { return models.View{} },
+		func(item *models.View) []interface{} {
+			return []interface{}{&item.Schema, &item.Name, &item.Description, &item.Definition}
+		},
 		"views")
 }
 
 func (a *PostgreSQLAnalyzer) buildViewQuery(schemas []string) string {
 	return a.buildSchemaFilterQuery(
-		`SELECT schemaname AS schema_name, viewname AS view_name FROM pg_catalog.pg_views WHERE true`,
+		`SELECT schemaname AS schema_name, viewname AS view_name, 
+		        COALESCE(pg_catalog.obj_description(format('%I.%I', schemaname, viewname)::regclass, 'pg_class'), '') AS description, 
+		        COALESCE(definition, '') AS definition 
+		 FROM pg_catalog.pg_views WHERE true`,
 		"schemaname",
 		"schemaname, viewname",
 		schemas,
 	)
 }
 
+func (a *PostgreSQLAnalyzer) GetViewColumns(ctx context.Context, view *models.View) ([]models.Column, error) {
+	return a.GetColumns(ctx, &models.Table{Schema: view.Schema, Name: view.Name})
+}
+
+func (a *PostgreSQLAnalyzer) GetViewDependencies(ctx context.Context, view *models.View) ([]models.Dependency, error) {
+	return a.getRelationDependencies(ctx, view.Schema, view.Name)
+}
+
+func (a *PostgreSQLAnalyzer) GetMaterializedViews(ctx context.Context, schemas []string) ([]models.MaterializedView, error) {
+	query := a.buildMaterializedViewQuery(schemas)
+
+	return querySchemaObjects(ctx, a.conn.queryer(), query, schemas, func() models.MaterializedView { return models.MaterializedView{} },
+		func(item *models.MaterializedView) []interface{} {
+			return []interface{}{&item.Schema, &item.Name, &item.Description, &item.Definition, &item.IsPopulated, &item.SizeBytes}
+		},
+		"materialized views")
+}
+
+func (a *PostgreSQLAnalyzer) buildMaterializedViewQuery(schemas []string) string {
+	return a.buildSchemaFilterQuery(
+		`SELECT n.nspname AS schema_name, c.relname AS view_name, 
+		        COALESCE(pg_catalog.obj_description(c.oid, 'pg_class'), '') AS description, 
+		        COALESCE(pg_catalog.pg_get_viewdef(c.oid, true), '') AS definition, 
+		        c.relispopulated AS is_populated, 
+		        pg_catalog.pg_total_relation_size(c.oid) AS size_bytes 
+		 FROM pg_catalog.pg_class c 
+		 INNER JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace 
+		 WHERE c.relkind = 'm'`,
+		"n.nspname",
+		"n.nspname, c.relname",
+		schemas,
+	)
+}
+
+// GetMaterializedViewColumns reads pg_attribute directly because
+// information_schema.columns does not list materialized views.
+func (a *PostgreSQLAnalyzer) GetMaterializedViewColumns(ctx context.Context, view *models.MaterializedView) ([]models.Column, error) {
+	query := `
+		SELECT 
+			a.attname AS column_name,
+			pg_catalog.format_type(a.atttypid, a.atttypmod) AS data_type,
+			NOT a.attnotnull AS is_nullable,
+			COALESCE(pg_catalog.col_description(a.attrelid, a.attnum), '') AS description
+		FROM 
+			pg_catalog.pg_attribute a
+		JOIN 
+			pg_catalog.pg_class c ON c.oid = a.attrelid
+		JOIN 
+			pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+		WHERE 
+			n.nspname = $1
+			AND c.relname = $2
+			AND a.attnum > 0
+			AND NOT a.attisdropped
+		ORDER BY 
+			a.attnum`
+
+	rows, err := a.conn.queryer().QueryContext(ctx, query, view.Schema, view.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query materialized view columns: %w", err)
+	}
+	defer rows.Close()
+
+	var columns []models.Column
+
+	for rows.Next() {
+		var col models.Column
+
+		if err := rows.Scan(&col.Name, &col.DataType, &col.IsNullable, &col.Description); err != nil {
+			return nil, fmt.Errorf("failed to scan materialized view column row: %w", err)
+		}
+
+		columns = append(columns, col)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating materialized view column rows: %w", err)
+	}
+
+	return columns, nil
+}
+
+func (a *PostgreSQLAnalyzer) GetMaterializedViewIndexes(ctx context.Context, view *models.MaterializedView) ([]models.Index, error) {
+	return a.GetIndexes(ctx, &models.Table{Schema: view.Schema, Name: view.Name})
+}
+
+func (a *PostgreSQLAnalyzer) GetMaterializedViewDependencies(ctx context.Context, view *models.MaterializedView) ([]models.Dependency, error) {
+	return a.getRelationDependencies(ctx, view.Schema, view.Name)
+}
+
+// getRelationDependencies follows pg_depend from the relation's rewrite rules
+// to the relations they reference, which is how PostgreSQL tracks what a view
+// or materialized view reads from.
+func (a *PostgreSQLAnalyzer) getRelationDependencies(ctx context.Context, schema, name string) ([]models.Dependency, error) {
+	query := `
+		SELECT DISTINCT
+			dn.nspname AS schema_name,
+			dc.relname AS relation_name,
+			CASE dc.relkind
+				WHEN 'r' THEN 'table'
+				WHEN 'p' THEN 'table'
+				WHEN 'v' THEN 'view'
+				WHEN 'm' THEN 'materialized view'
+				WHEN 'f' THEN 'foreign table'
+				ELSE dc.relkind::text
+			END AS relation_kind
+		FROM 
+			pg_catalog.pg_rewrite rw
+		JOIN 
+			pg_catalog.pg_class v ON v.oid = rw.ev_class
+		JOIN 
+			pg_catalog.pg_namespace vn ON vn.oid = v.relnamespace
+		JOIN 
+			pg_catalog.pg_depend d 
+			ON d.classid = 'pg_catalog.pg_rewrite'::regclass
+			AND d.objid = rw.oid
+			AND d.refclassid = 'pg_catalog.pg_class'::regclass
+		JOIN 
+			pg_catalog.pg_class dc ON dc.oid = d.refobjid
+		JOIN 
+			pg_catalog.pg_namespace dn ON dn.oid = dc.relnamespace
+		WHERE 
+			vn.nspname = $1
+			AND v.relname = $2
+			AND dc.oid <> v.oid
+		ORDER BY 
+			dn.nspname, dc.relname`
+
+	rows, err := a.conn.queryer().QueryContext(ctx, query, schema, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query dependencies: %w", err)
+	}
+	defer rows.Close()
+
+	var dependencies []models.Dependency
+
+	for rows.Next() {
+		var dep models.Dependency
+
+		if err := rows.Scan(&dep.Schema, &dep.Name, &dep.Kind); err != nil {
+			return nil, fmt.Errorf("failed to scan dependency row: %w", err)
+		}
+
+		dependencies = append(dependencies, dep)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating dependency rows: %w", err)
+	}
+
+	return dependencies, nil
+}
+
+// GetTypes returns enum, domain, composite and range types, leaving out the
+// types that belong to extensions.
+func (a *PostgreSQLAnalyzer) GetTypes(ctx context.Context, schemas []string) ([]models.Type, error) {
+	query := a.buildTypeQuery(schemas)
+	args := make([]interface{}, len(schemas))
+
+	for i, schema := range schemas {
+		args[i] = schema
+	}
+
+	rows, err := a.conn.queryer().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query types: %w", err)
+	}
+	defer rows.Close()
+
+	var types []models.Type
+
+	for rows.Next() {
+		var (
+			typ            models.Type
+			defaultValue   sql.NullString
+			attributeNames []string
+			attributeTypes []string
+		)
+
+		if err := rows.Scan(
+			&typ.Schema,
+			&typ.Name,
+			&typ.Kind,
+			&typ.Description,
+			pq.Array(&typ.Labels),
+			&typ.BaseType,
+			&defaultValue,
+			&typ.NotNull,
+			pq.Array(&typ.Constraints),
+			pq.Array(&attributeNames),
+			pq.Array(&attributeTypes),
+			&typ.Subtype,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan type row: %w", err)
+		}
+
+		if defaultValue.Valid {
+			typ.DefaultValue = &defaultValue.String
+		}
+
+		for i := range attributeNames {
+			typ.Attributes = append(typ.Attributes, models.TypeAttribute{Name: attributeNames[i], DataType: attributeTypes[i]})
+		}
+
+		types = append(types, typ)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating type rows: %w", err)
+	}
+
+	return types, nil
+}
+
+func (a *PostgreSQLAnalyzer) buildTypeQuery(schemas []string) string {
+	return a.buildSchemaFilterQuery(
+		`SELECT n.nspname AS schema_name, t.typname AS type_name, 
+		        CASE t.typtype 
+		            WHEN 'e' THEN 'enum' 
+		            WHEN 'd' THEN 'domain' 
+		            WHEN 'c' THEN 'composite' 
+		            WHEN 'r' THEN 'range' 
+		        END AS kind, 
+		        COALESCE(pg_catalog.obj_description(t.oid, 'pg_type'), '') AS description, 
+		        ARRAY(SELECT e.enumlabel FROM pg_catalog.pg_enum e 
+		              WHERE e.enumtypid = t.oid ORDER BY e.enumsortorder) AS labels, 
+		        CASE WHEN t.typtype = 'd' THEN pg_catalog.format_type(t.typbasetype, t.typtypmod) ELSE '' END AS base_type, 
+		        t.typdefault AS default_value, 
+		        t.typnotnull AS not_null, 
+		        ARRAY(SELECT pg_catalog.pg_get_constraintdef(con.oid, true) FROM pg_catalog.pg_constraint con 
+		              WHERE con.contypid = t.oid ORDER BY con.conname) AS constraints, 
+		        ARRAY(SELECT a.attname FROM pg_catalog.pg_attribute a 
+		              WHERE a.attrelid = t.typrelid AND a.attnum > 0 AND NOT a.attisdropped ORDER BY a.attnum) AS attribute_names, 
+		        ARRAY(SELECT pg_catalog.format_type(a.atttypid, a.atttypmod) FROM pg_catalog.pg_attribute a 
+		              WHERE a.attrelid = t.typrelid AND a.attnum > 0 AND NOT a.attisdropped ORDER BY a.attnum) AS attribute_types, 
+		        COALESCE((SELECT pg_catalog.format_type(r.rngsubtype, NULL) FROM pg_catalog.pg_range r 
+		                  WHERE r.rngtypid = t.oid), '') AS subtype 
+		 FROM pg_catalog.pg_type t 
+		 INNER JOIN pg_catalog.pg_namespace n ON n.oid = t.typnamespace 
+		 WHERE (t.typtype IN ('e', 'd', 'r') 
+		        OR (t.typtype = 'c' AND EXISTS (SELECT 1 FROM pg_catalog.pg_class c 
+		                                        WHERE c.oid = t.typrelid AND c.relkind = 'c'))) 
+		   AND NOT EXISTS (SELECT 1 FROM pg_catalog.pg_depend d 
+		                   WHERE d.classid = 'pg_catalog.pg_type'::regclass AND d.objid = t.oid AND d.deptype = 'e')`,
+		"n.nspname",
+		"n.nspname, t.typname",
+		schemas,
+	)
+}
+
+// GetRoutines returns functions, procedures and aggregates, leaving out the
+// routines that belong to extensions.
+func (a *PostgreSQLAnalyzer) GetRoutines(ctx context.Context, schemas []string, includeSource bool) ([]models.Routine, error) {
+	return querySchemaObjects(ctx, a.conn.queryer(), a.buildRoutineQuery(schemas, includeSource), schemas,
+		func() models.Routine { return models.Routine{} },
+		func(r *models.Routine) []interface{} {
+			return []interface{}{
+				&r.Schema, &r.Name, &r.Kind, &r.Arguments, &r.ReturnType, &r.Language,
+				&r.Volatility, &r.SecurityDefiner, &r.Owner, &r.Description, &r.Source,
+			}
+		},
+		"routines")
+}
+
+func (a *PostgreSQLAnalyzer) buildRoutineQuery(schemas []string, includeSource bool) string {
+	// Bodies of C and internal routines are symbol names, not source code
+	source := "''"
+	if includeSource {
+		source = "CASE WHEN l.lanname IN ('c', 'internal') OR p.prokind = 'a' THEN '' ELSE p.prosrc END"
+	}
+
+	return a.buildSchemaFilterQuery(
+		`SELECT n.nspname AS schema_name, p.proname AS routine_name, 
+		        CASE p.prokind 
+		            WHEN 'p' THEN 'procedure' 
+		            WHEN 'a' THEN 'aggregate' 
+		            WHEN 'w' THEN 'window' 
+		            ELSE 'function' 
+		        END AS kind, 
+		        pg_catalog.pg_get_function_identity_arguments(p.oid) AS arguments, 
+		        COALESCE(pg_catalog.pg_get_function_result(p.oid), '') AS return_type, 
+		        l.lanname AS language, 
+		        CASE p.provolatile 
+		            WHEN 'i' THEN 'IMMUTABLE' 
+		            WHEN 's' THEN 'STABLE' 
+		            ELSE 'VOLATILE' 
+		        END AS volatility, 
+		        p.prosecdef AS security_definer, 
+		        pg_catalog.pg_get_userbyid(p.proowner) AS owner, 
+		        COALESCE(pg_catalog.obj_description(p.oid, 'pg_proc'), '') AS description, 
+		        `+source+` AS source 
+		 FROM pg_catalog.pg_proc p 
+		 INNER JOIN pg_catalog.pg_namespace n ON n.oid = p.pronamespace 
+		 INNER JOIN pg_catalog.pg_language l ON l.oid = p.prolang 
+		 WHERE NOT EXISTS (SELECT 1 FROM pg_catalog.pg_depend d 
+		                   WHERE d.classid = 'pg_catalog.pg_proc'::regclass AND d.objid = p.oid AND d.deptype = 'e')`,
+		"n.nspname",
+		"n.nspname, p.proname, arguments",
+		schemas,
+	)
+}
+
+// GetGrants expands the ACLs of relations, columns, routines and schemas with
+// aclexplode. Objects that were never granted on carry a NULL ACL, which
+// means the owner's default privileges; acldefault fills those in so the
+// owner still shows up. Predefined roles, whose names are reserved to start
+// with "pg_", are flagged as system roles; the bootstrap superuser is not, as
+// it usually owns the application's objects.
+func (a *PostgreSQLAnalyzer) GetGrants(ctx context.Context, schemas []string) ([]models.Grant, error) {
+	return querySchemaObjects(ctx, a.conn.queryer(), a.buildGrantQuery(schemas), schemas,
+		func() models.Grant { return models.Grant{} },
+		func(g *models.Grant) []interface{} {
+			return []interface{}{
+				&g.ObjectType, &g.Schema, &g.Object, &g.Column, &g.Grantee, &g.Grantor,
+				&g.Privilege, &g.IsGrantable, &g.IsSystemRole,
+			}
+		},
+		"grants")
+}
+
+func (a *PostgreSQLAnalyzer) buildGrantQuery(schemas []string) string {
+	return a.buildSchemaFilterQuery(
+		`SELECT g.object_type, g.schema_name, g.object_name, g.column_name, 
+		        CASE WHEN g.grantee = 0 THEN 'PUBLIC' ELSE pg_catalog.pg_get_userbyid(g.grantee) END AS grantee_name, 
+		        pg_catalog.pg_get_userbyid(g.grantor) AS grantor_name, 
+		        g.privilege_type, g.is_grantable, 
+		        g.grantee <> 0 AND pg_catalog.pg_get_userbyid(g.grantee) LIKE 'pg\_%' AS is_system_role 
+		 FROM ( 
+		     SELECT CASE c.relkind 
+		                WHEN 'S' THEN 'sequence' 
+		                WHEN 'v' THEN 'view' 
+		                WHEN 'm' THEN 'materialized view' 
+		                WHEN 'f' THEN 'foreign table' 
+		                ELSE 'table' 
+		            END AS object_type, 
+		            n.nspname AS schema_name, c.relname AS object_name, '' AS column_name, 
+		            acl.grantee, acl.grantor, acl.privilege_type, acl.is_grantable 
+		     FROM pg_catalog.pg_class c 
+		     INNER JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace 
+		     CROSS JOIN LATERAL pg_catalog.aclexplode(COALESCE(c.relacl, 
+		         pg_catalog.acldefault(CASE WHEN c.relkind = 'S' THEN 's' ELSE 'r' END::"char", c.relowner))) acl 
+		     WHERE c.relkind IN ('r', 'p', 'v', 'm', 'f', 'S') 
+		     UNION ALL 
+		     SELECT 'column', n.nspname, c.relname, a.attname, 
+		            acl.grantee, acl.grantor, acl.privilege_type, acl.is_grantable 
+		     FROM pg_catalog.pg_attribute a 
+		     INNER JOIN pg_catalog.pg_class c ON c.oid = a.attrelid 
+		     INNER JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace 
+		     CROSS JOIN LATERAL pg_catalog.aclexplode(a.attacl) acl 
+		     WHERE c.relkind IN ('r', 'p', 'v', 'm', 'f') AND a.attnum > 0 AND NOT a.attisdropped AND a.attacl IS NOT NULL 
+		     UNION ALL 
+		     SELECT CASE p.prokind WHEN 'p' THEN 'procedure' ELSE 'function' END, n.nspname, 
+		            p.proname || '(' || pg_catalog.pg_get_function_identity_arguments(p.oid) || ')', '', 
+		            acl.grantee, acl.grantor, acl.privilege_type, acl.is_grantable 
+		     FROM pg_catalog.pg_proc p 
+		     INNER JOIN pg_catalog.pg_namespace n ON n.oid = p.pronamespace 
+		     CROSS JOIN LATERAL pg_catalog.aclexplode(COALESCE(p.proacl, pg_catalog.acldefault('f', p.proowner))) acl 
+		     WHERE NOT EXISTS (SELECT 1 FROM pg_catalog.pg_depend d 
+		                       WHERE d.classid = 'pg_catalog.pg_proc'::regclass AND d.objid = p.oid AND d.deptype = 'e') 
+		     UNION ALL 
+		     SELECT 'schema', n.nspname, n.nspname, '', 
+		            acl.grantee, acl.grantor, acl.privilege_type, acl.is_grantable 
+		     FROM pg_catalog.pg_namespace n 
+		     CROSS JOIN LATERAL pg_catalog.aclexplode(COALESCE(n.nspacl, pg_catalog.acldefault('n', n.nspowner))) acl 
+		     WHERE n.nspname NOT LIKE 'pg\_%' 
+		 ) g 
+		 WHERE true`,
+		"g.schema_name",
+		"g.schema_name, g.object_type <> 'schema', g.object_name, g.column_name, grantee_name, g.privilege_type",
+		schemas,
+	)
+}
+
 func (a *PostgreSQLAnalyzer) GetSequences(ctx context.Context, schemas []string) ([]models.Sequence, error) {
 	query := a.buildSequenceQuery(schemas)
 	args := make([]interface{}, len(schemas))
@@ -462,7 +1658,7 @@ func (a *PostgreSQLAnalyzer) GetSequences(ctx context.Context, schemas []string)
 		args[i] = schema
 	}
 
-	rows, err := a.conn.db.QueryContext(ctx, query, args...)
+	rows, err := a.conn.queryer().QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query sequences: %w", err)
 	}
@@ -471,11 +1667,24 @@ func (a *PostgreSQLAnalyzer) GetSequences(ctx context.Context, schemas []string)
 	var sequences []models.Sequence
 
 	for rows.Next() {
-		var seq models.Sequence
-		if err := rows.Scan(&seq.Schema, &seq.Name, &seq.DataType, &seq.StartValue, &seq.MinValue, &seq.MaxValue, &seq.Increment); err != nil {
+		var (
+			seq            models.Sequence
+			lastValue      sql.NullInt64
+			columnMaxValue sql.NullInt64
+		)
+
+		if err := rows.Scan(
+			&seq.Schema, &seq.Name, &seq.DataType, &seq.StartValue, &seq.MinValue, &seq.MaxValue, &seq.Increment,
+			&lastValue, &seq.Description, &seq.OwnerTable, &seq.OwnerColumn, &seq.IsIdentity, &columnMaxValue,
+		); err != nil {
 			return nil, fmt.Errorf("failed to scan sequence row: %w", err)
 		}
 
+		if lastValue.Valid {
+			seq.LastValue = &lastValue.Int64
+			seq.PercentUsed = sequencePercentUsed(&seq, columnMaxValue)
+		}
+
 		sequences = append(sequences, seq)
 	}
 
@@ -486,7 +1695,7 @@ func (a *PostgreSQLAnalyzer) GetSequences(ctx context.Context, schemas []string)
 	return sequences, nil
 }
 
-func querySchemaObjects[T any](ctx context.Context, db *sql.DB, query string, schemas []string,
+func querySchemaObjects[T any](ctx context.Context, db queryer, query string, schemas []string,
 	newItem func() T, scanFields func(*T) []interface{}, objectType string) ([]T, error) {
 	args := make([]interface{}, len(schemas))
 	for i, schema := range schemas {
@@ -518,6 +1727,17 @@ func querySchemaObjects[T any](ctx context.Context, db *sql.DB, query string, sc
 	return items, nil
 }
 
+// qualifiedNames returns the "schema.name" of each table, the key bulk
+// queries filter on and return their results under.
+func qualifiedNames(tables []models.Table) []string {
+	names := make([]string, len(tables))
+	for i := range tables {
+		names[i] = tables[i].Schema + "." + tables[i].Name
+	}
+
+	return names
+}
+
 func (a *PostgreSQLAnalyzer) buildSchemaFilterQuery(baseQuery, schemaColumn, orderBy string, schemas []string) string {
 	whereClause := " AND "
 
@@ -538,11 +1758,467 @@ func (a *PostgreSQLAnalyzer) buildSchemaFilterQuery(baseQuery, schemaColumn, ord
 	return baseQuery + whereClause + fmt.Sprintf(" ORDER BY %s", orderBy)
 }
 
+// buildSequenceQuery reads each sequence with the column that owns it: an
+// auto dependency (deptype 'a') for serial and OWNED BY, an internal one
+// ('i') for identity columns. column_max_value is the owning column's limit
+// when it is narrower than bigint.
 func (a *PostgreSQLAnalyzer) buildSequenceQuery(schemas []string) string {
 	return a.buildSchemaFilterQuery(
-		`SELECT schemaname AS schema_name, sequencename AS sequence_name, data_type, start_value, min_value, max_value, increment_by FROM pg_catalog.pg_sequences WHERE true`,
-		"schemaname",
-		"schemaname, sequencename",
+		`SELECT s.schemaname AS schema_name, s.sequencename AS sequence_name, s.data_type, s.start_value, s.min_value, s.max_value, s.increment_by, 
+		        s.last_value, 
+		        COALESCE(pg_catalog.obj_description(sc.oid, 'pg_class'), '') AS description, 
+		        COALESCE(tn.nspname || '.' || t.relname, '') AS owner_table, 
+		        COALESCE(att.attname, '') AS owner_column, 
+		        COALESCE(d.deptype = 'i', false) AS is_identity, 
+		        CASE att.atttypid 
+		            WHEN 'pg_catalog.int2'::pg_catalog.regtype THEN 32767 
+		            WHEN 'pg_catalog.int4'::pg_catalog.regtype THEN 2147483647 
+		        END AS column_max_value 
+		 FROM pg_catalog.pg_sequences s 
+		 JOIN pg_catalog.pg_namespace sn ON sn.nspname = s.schemaname 
+		 JOIN pg_catalog.pg_class sc ON sc.relname = s.sequencename AND sc.relnamespace = sn.oid 
+		 LEFT JOIN pg_catalog.pg_depend d 
+		   ON d.classid = 'pg_catalog.pg_class'::pg_catalog.regclass 
+		   AND d.objid = sc.oid 
+		   AND d.refclassid = 'pg_catalog.pg_class'::pg_catalog.regclass 
+		   AND d.deptype IN ('a', 'i') 
+		 LEFT JOIN pg_catalog.pg_class t ON t.oid = d.refobjid 
+		 LEFT JOIN pg_catalog.pg_namespace tn ON tn.oid = t.relnamespace 
+		 LEFT JOIN pg_catalog.pg_attribute att ON att.attrelid = d.refobjid AND att.attnum = d.refobjsubid 
+		 WHERE true`,
+		"s.schemaname",
+		"s.schemaname, s.sequencename",
+		schemas,
+	)
+}
+
+// sequencePercentUsed reports how much of its range a used sequence has
+// consumed, counting from the end it starts at towards the end it moves to.
+// An ascending sequence that feeds a narrower column is measured against the
+// column's maximum, since inserts fail once that is exceeded.
+func sequencePercentUsed(seq *models.Sequence, columnMaxValue sql.NullInt64) float64 {
+	minValue, maxValue := float64(seq.MinValue), float64(seq.MaxValue)
+	lastValue := float64(*seq.LastValue)
+
+	used := lastValue - minValue
+	if seq.Increment < 0 {
+		used = maxValue - lastValue
+	} else if columnMaxValue.Valid && float64(columnMaxValue.Int64) < maxValue {
+		maxValue = float64(columnMaxValue.Int64)
+	}
+
+	if maxValue <= minValue {
+		return 0
+	}
+
+	return used * 100 / (maxValue - minValue)
+}
+
+// GetEventTriggers returns the event triggers in the database, leaving out
+// those created by extensions.
+func (a *PostgreSQLAnalyzer) GetEventTriggers(ctx context.Context) ([]models.EventTrigger, error) {
+	query := `
+		SELECT 
+			e.evtname,
+			e.evtevent,
+			COALESCE(e.evttags, '{}') AS tags,
+			p.proname AS function_name,
+			pn.nspname AS function_schema,
+			CASE e.evtenabled
+				WHEN 'D' THEN 'DISABLED'
+				WHEN 'R' THEN 'REPLICA'
+				WHEN 'A' THEN 'ALWAYS'
+				ELSE 'ENABLED'
+			END AS enabled,
+			pg_catalog.pg_get_userbyid(e.evtowner) AS owner,
+			COALESCE(pg_catalog.obj_description(e.oid, 'pg_event_trigger'), '') AS description
+		FROM 
+			pg_catalog.pg_event_trigger e
+		JOIN 
+			pg_catalog.pg_proc p ON p.oid = e.evtfoid
+		JOIN 
+			pg_catalog.pg_namespace pn ON pn.oid = p.pronamespace
+		WHERE 
+			NOT EXISTS (
+				SELECT 1 FROM pg_catalog.pg_depend d
+				WHERE d.classid = 'pg_catalog.pg_event_trigger'::pg_catalog.regclass
+				  AND d.objid = e.oid
+				  AND d.deptype = 'e'
+			)
+		ORDER BY 
+			e.evtname`
+
+	rows, err := a.conn.queryer().QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query event triggers: %w", err)
+	}
+	defer rows.Close()
+
+	var eventTriggers []models.EventTrigger
+
+	for rows.Next() {
+		var trigger models.EventTrigger
+
+		if err := rows.Scan(
+			&trigger.Name,
+			&trigger.Event,
+			pq.Array(&trigger.Tags),
+			&trigger.Function,
+			&trigger.FunctionSchema,
+			&trigger.Enabled,
+			&trigger.Owner,
+			&trigger.Description,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan event trigger row: %w", err)
+		}
+
+		eventTriggers = append(eventTriggers, trigger)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating event trigger rows: %w", err)
+	}
+
+	return eventTriggers, nil
+}
+
+func (a *PostgreSQLAnalyzer) GetRules(ctx context.Context, schemas []string) ([]models.Rule, error) {
+	query := a.buildRuleQuery(schemas)
+
+	return querySchemaObjects(ctx, a.conn.queryer(), query, schemas, func() models.Rule { return models.Rule{} },
+		func(item *models.Rule) []interface{} {
+			return []interface{}{
+				&item.Schema, &item.Table, &item.Name, &item.Event, &item.IsInstead, &item.Enabled, &item.Definition,
+			}
+		},
+		"rules")
+}
+
+// buildRuleQuery lists rewrite rules other than the _RETURN rule every view
+// carries as its definition.
+func (a *PostgreSQLAnalyzer) buildRuleQuery(schemas []string) string {
+	return a.buildSchemaFilterQuery(
+		`SELECT n.nspname AS schema_name, c.relname AS table_name, r.rulename AS rule_name, 
+		        CASE r.ev_type 
+		            WHEN '1' THEN 'SELECT' 
+		            WHEN '2' THEN 'UPDATE' 
+		            WHEN '3' THEN 'INSERT' 
+		            WHEN '4' THEN 'DELETE' 
+		        END AS event, 
+		        r.is_instead, 
+		        CASE r.ev_enabled 
+		            WHEN 'D' THEN 'DISABLED' 
+		            WHEN 'R' THEN 'REPLICA' 
+		            WHEN 'A' THEN 'ALWAYS' 
+		            ELSE 'ENABLED' 
+		        END AS enabled, 
+		        pg_catalog.pg_get_ruledef(r.oid, true) AS definition 
+		 FROM pg_catalog.pg_rewrite r 
+		 JOIN pg_catalog.pg_class c ON c.oid = r.ev_class 
+		 JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace 
+		 WHERE r.rulename <> '_RETURN'`,
+		"n.nspname",
+		"n.nspname, c.relname, r.rulename",
 		schemas,
 	)
 }
+
+// GetPublications returns publications with the tables pg_publication_tables
+// resolves for them, which already expands FOR ALL TABLES and
+// FOR TABLES IN SCHEMA.
+func (a *PostgreSQLAnalyzer) GetPublications(ctx context.Context) ([]models.Publication, error) {
+	query := `
+		SELECT 
+			p.pubname,
+			pg_catalog.pg_get_userbyid(p.pubowner) AS owner,
+			p.puballtables,
+			ARRAY_REMOVE(ARRAY[
+				CASE WHEN p.pubinsert THEN 'INSERT' END,
+				CASE WHEN p.pubupdate THEN 'UPDATE' END,
+				CASE WHEN p.pubdelete THEN 'DELETE' END,
+				CASE WHEN p.pubtruncate THEN 'TRUNCATE' END
+			], NULL) AS operations,
+			ARRAY(
+				SELECT pt.schemaname || '.' || pt.tablename
+				FROM pg_catalog.pg_publication_tables pt
+				WHERE pt.pubname = p.pubname
+				ORDER BY 1
+			) AS tables
+		FROM 
+			pg_catalog.pg_publication p
+		ORDER BY 
+			p.pubname`
+
+	rows, err := a.conn.queryer().QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query publications: %w", err)
+	}
+	defer rows.Close()
+
+	var publications []models.Publication
+
+	for rows.Next() {
+		var publication models.Publication
+
+		if err := rows.Scan(
+			&publication.Name,
+			&publication.Owner,
+			&publication.AllTables,
+			pq.Array(&publication.Operations),
+			pq.Array(&publication.Tables),
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan publication row: %w", err)
+		}
+
+		publications = append(publications, publication)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating publication rows: %w", err)
+	}
+
+	return publications, nil
+}
+
+// documentedOptions are the FDW option names known not to hold credentials.
+// Wrappers name their credential options freely (odbc_PWD,
+// aws_secret_access_key, a dsn with an embedded password, ...), so every
+// other option is left out of the documentation.
+var documentedOptions = map[string]bool{
+	"host":                true,
+	"hostaddr":            true,
+	"port":                true,
+	"dbname":              true,
+	"database":            true,
+	"user":                true,
+	"username":            true,
+	"servername":          true,
+	"schema_name":         true,
+	"table_name":          true,
+	"column_name":         true,
+	"sslmode":             true,
+	"application_name":    true,
+	"connect_timeout":     true,
+	"updatable":           true,
+	"truncatable":         true,
+	"use_remote_estimate": true,
+	"fdw_startup_cost":    true,
+	"fdw_tuple_cost":      true,
+	"fetch_size":          true,
+	"batch_size":          true,
+	"async_capable":       true,
+	"keep_connections":    true,
+	"password_required":   true,
+	"extensions":          true,
+	"filename":            true,
+	"format":              true,
+	"header":              true,
+	"delimiter":           true,
+	"encoding":            true,
+}
+
+// redactOptions keeps only the documented options from a list of
+// "name=value" pairs.
+func redactOptions(options []string) []string {
+	var redacted []string
+
+	for _, option := range options {
+		name, _, _ := strings.Cut(option, "=")
+		if !documentedOptions[strings.ToLower(name)] {
+			continue
+		}
+
+		redacted = append(redacted, option)
+	}
+
+	return redacted
+}
+
+// optionNames strips the values from a list of "name=value" pairs.
+func optionNames(options []string) []string {
+	names := make([]string, 0, len(options))
+
+	for _, option := range options {
+		name, _, _ := strings.Cut(option, "=")
+		names = append(names, name)
+	}
+
+	return names
+}
+
+func (a *PostgreSQLAnalyzer) GetForeignDataWrappers(ctx context.Context) ([]models.ForeignDataWrapper, error) {
+	query := `
+		SELECT 
+			w.fdwname,
+			pg_catalog.pg_get_userbyid(w.fdwowner) AS owner,
+			COALESCE(NULLIF(w.fdwhandler, 0)::regproc::text, '') AS handler,
+			COALESCE(NULLIF(w.fdwvalidator, 0)::regproc::text, '') AS validator,
+			COALESCE(w.fdwoptions, '{}') AS options
+		FROM 
+			pg_catalog.pg_foreign_data_wrapper w
+		ORDER BY 
+			w.fdwname`
+
+	rows, err := a.conn.queryer().QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query foreign data wrappers: %w", err)
+	}
+	defer rows.Close()
+
+	var wrappers []models.ForeignDataWrapper
+
+	for rows.Next() {
+		var wrapper models.ForeignDataWrapper
+
+		if err := rows.Scan(
+			&wrapper.Name,
+			&wrapper.Owner,
+			&wrapper.Handler,
+			&wrapper.Validator,
+			pq.Array(&wrapper.Options),
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan foreign data wrapper row: %w", err)
+		}
+
+		wrapper.Options = redactOptions(wrapper.Options)
+
+		wrappers = append(wrappers, wrapper)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating foreign data wrapper rows: %w", err)
+	}
+
+	return wrappers, nil
+}
+
+// GetForeignServers reads user mappings through pg_user_mappings, which
+// already hides the options of mappings the current role may not see.
+func (a *PostgreSQLAnalyzer) GetForeignServers(ctx context.Context) ([]models.ForeignServer, error) {
+	query := `
+		SELECT 
+			s.srvname,
+			w.fdwname AS wrapper,
+			COALESCE(s.srvtype, '') AS server_type,
+			COALESCE(s.srvversion, '') AS server_version,
+			pg_catalog.pg_get_userbyid(s.srvowner) AS owner,
+			COALESCE(s.srvoptions, '{}') AS options
+		FROM 
+			pg_catalog.pg_foreign_server s
+		JOIN 
+			pg_catalog.pg_foreign_data_wrapper w ON w.oid = s.srvfdw
+		ORDER BY 
+			s.srvname`
+
+	rows, err := a.conn.queryer().QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query foreign servers: %w", err)
+	}
+	defer rows.Close()
+
+	var servers []models.ForeignServer
+
+	for rows.Next() {
+		var server models.ForeignServer
+
+		if err := rows.Scan(
+			&server.Name,
+			&server.Wrapper,
+			&server.Type,
+			&server.Version,
+			&server.Owner,
+			pq.Array(&server.Options),
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan foreign server row: %w", err)
+		}
+
+		server.Options = redactOptions(server.Options)
+
+		servers = append(servers, server)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating foreign server rows: %w", err)
+	}
+
+	for i := range servers {
+		mappings, err := a.getUserMappings(ctx, servers[i].Name)
+		if err != nil {
+			return nil, err
+		}
+
+		servers[i].UserMappings = mappings
+	}
+
+	return servers, nil
+}
+
+func (a *PostgreSQLAnalyzer) getUserMappings(ctx context.Context, server string) ([]models.UserMapping, error) {
+	query := `
+		SELECT 
+			um.usename,
+			COALESCE(um.umoptions, '{}') AS options
+		FROM 
+			pg_catalog.pg_user_mappings um
+		WHERE 
+			um.srvname = $1
+		ORDER BY 
+			um.usename`
+
+	rows, err := a.conn.queryer().QueryContext(ctx, query, server)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query user mappings: %w", err)
+	}
+	defer rows.Close()
+
+	var mappings []models.UserMapping
+
+	for rows.Next() {
+		var mapping models.UserMapping
+
+		if err := rows.Scan(&mapping.User, pq.Array(&mapping.Options)); err != nil {
+			return nil, fmt.Errorf("failed to scan user mapping row: %w", err)
+		}
+
+		mapping.Options = optionNames(mapping.Options)
+
+		mappings = append(mappings, mapping)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating user mapping rows: %w", err)
+	}
+
+	return mappings, nil
+}
+
+func (a *PostgreSQLAnalyzer) GetForeignTables(ctx context.Context, schemas []string) ([]models.ForeignTable, error) {
+	query := a.buildForeignTableQuery(schemas)
+
+	return querySchemaObjects(ctx, a.conn.queryer(), query, schemas, func() models.ForeignTable { return models.ForeignTable{} },
+		func(item *models.ForeignTable) []interface{} {
+			return []interface{}{&item.Schema, &item.Name, &item.Description, &item.Server, pq.Array(&item.Options)}
+		},
+		"foreign tables")
+}
+
+func (a *PostgreSQLAnalyzer) buildForeignTableQuery(schemas []string) string {
+	return a.buildSchemaFilterQuery(
+		`SELECT n.nspname AS schema_name, c.relname AS table_name, 
+		        COALESCE(pg_catalog.obj_description(c.oid, 'pg_class'), '') AS description, 
+		        s.srvname AS server_name, 
+		        COALESCE(ft.ftoptions, '{}') AS options 
+		 FROM pg_catalog.pg_foreign_table ft 
+		 JOIN pg_catalog.pg_class c ON c.oid = ft.ftrelid 
+		 JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace 
+		 JOIN pg_catalog.pg_foreign_server s ON s.oid = ft.ftserver 
+		 WHERE c.relkind = 'f'`,
+		"n.nspname",
+		"n.nspname, c.relname",
+		schemas,
+	)
+}
+
+func (a *PostgreSQLAnalyzer) GetForeignTableColumns(ctx context.Context, table *models.ForeignTable) ([]models.Column, error) {
+	return a.GetColumns(ctx, &models.Table{Schema: table.Schema, Name: table.Name})
+}
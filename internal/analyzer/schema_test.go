@@ -2,8 +2,11 @@ package analyzer
 
 import (
 	"context"
+	"database/sql"
+	"math"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/orchard9/pg-goer/pkg/models"
 )
@@ -246,7 +249,7 @@ func TestGetTableRowCounts(t *testing.T) {
 			defer conn.Close()
 
 			analyzer := NewSchemaAnalyzer(conn)
-			rowCounts, err := analyzer.GetTableRowCounts(context.Background(), tt.tables)
+			rowCounts, err := analyzer.GetTableRowCounts(context.Background(), tt.tables, RowCountOptions{Method: models.RowCountExact})
 
 			if tt.expectCounts && err != nil {
 				t.Errorf("expected row counts but got error: %v", err)
@@ -266,3 +269,133 @@ func TestGetTableRowCounts(t *testing.T) {
 func contains(str, substr string) bool {
 	return strings.Contains(str, substr)
 }
+
+func TestSequencePercentUsed(t *testing.T) {
+	tests := []struct {
+		name           string
+		seq            models.Sequence
+		lastValue      int64
+		columnMaxValue sql.NullInt64
+		expected       float64
+	}{
+		{
+			name:      "ascending sequence",
+			seq:       models.Sequence{MinValue: 1, MaxValue: 101, Increment: 1},
+			lastValue: 51,
+			expected:  50,
+		},
+		{
+			name:           "bigint sequence feeding an integer column",
+			seq:            models.Sequence{MinValue: 0, MaxValue: 9223372036854775807, Increment: 1},
+			lastValue:      1717986918,
+			columnMaxValue: sql.NullInt64{Int64: 2147483647, Valid: true},
+			expected:       80,
+		},
+		{
+			name:      "descending sequence",
+			seq:       models.Sequence{MinValue: -100, MaxValue: 0, Increment: -1},
+			lastValue: -25,
+			expected:  25,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.seq.LastValue = &tt.lastValue
+
+			got := sequencePercentUsed(&tt.seq, tt.columnMaxValue)
+			if math.Abs(got-tt.expected) > 0.01 {
+				t.Errorf("expected %.2f%% used, got %.2f%%", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestRedactOptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  []string
+		expected []string
+	}{
+		{
+			name:     "server options are kept",
+			options:  []string{"host=db.internal", "port=5432", "dbname=sales"},
+			expected: []string{"host=db.internal", "port=5432", "dbname=sales"},
+		},
+		{
+			name:     "credentials are dropped",
+			options:  []string{"user=reporter", "password=hunter2", "PASSWORD=x", "sslpassword=y"},
+			expected: []string{"user=reporter"},
+		},
+		{
+			name: "wrapper-specific credentials are dropped",
+			options: []string{
+				"odbc_PWD=x", "aws_secret_access_key=x", "secret_key=x", "access_key=x", "client_secret=x",
+				"private_key=x", "dsn=Driver=pg;UID=app;PWD=x", "connstr=postgres://app:x@db/sales", "table_name=orders",
+			},
+			expected: []string{"table_name=orders"},
+		},
+		{
+			name:     "flags that only mention passwords are kept",
+			options:  []string{"password_required=false"},
+			expected: []string{"password_required=false"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := redactOptions(tt.options)
+			if strings.Join(got, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestOptionNames(t *testing.T) {
+	got := optionNames([]string{"user=reporter", "password=hunter2"})
+	if strings.Join(got, ",") != "user,password" {
+		t.Errorf("expected only option names, got %v", got)
+	}
+}
+
+func TestCountRowsQuery(t *testing.T) {
+	table := &models.Table{Schema: "shop", Name: "order`items"}
+
+	tests := []struct {
+		name     string
+		timeout  time.Duration
+		mariaDB  bool
+		expected string
+	}{
+		{
+			name:     "no timeout",
+			expected: "SELECT COUNT(*) FROM `shop`.`order``items`",
+		},
+		{
+			name:     "mariadb",
+			timeout:  1500 * time.Millisecond,
+			mariaDB:  true,
+			expected: "SET STATEMENT max_statement_time = 1.5 FOR SELECT COUNT(*) FROM `shop`.`order``items`",
+		},
+		{
+			name:     "mariadb without exponent",
+			timeout:  10 * time.Microsecond,
+			mariaDB:  true,
+			expected: "SET STATEMENT max_statement_time = 0.00001 FOR SELECT COUNT(*) FROM `shop`.`order``items`",
+		},
+		{
+			name:     "mysql",
+			timeout:  1500 * time.Millisecond,
+			expected: "SELECT /*+ MAX_EXECUTION_TIME(1500) */ COUNT(*) FROM `shop`.`order``items`",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := countRowsQuery(table, tt.timeout, tt.mariaDB); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
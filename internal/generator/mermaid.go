@@ -30,12 +30,108 @@ func (g *MermaidGenerator) GenerateER(schema *models.Schema) (string, error) {
 
 	// Generate table definitions
 	for i := range schema.Tables {
-		g.writeTableDefinition(&sb, &schema.Tables[i])
+		g.writeTableDefinition(&sb, schema.Tables[i].Name, schema.Tables[i].Columns)
 	}
 
+	g.writeForeignTables(&sb, schema.ForeignTables)
+
 	return sb.String(), nil
 }
 
+// writeForeignTables draws foreign tables after the local ones, each marked
+// by a comment naming the remote server their rows live on. erDiagram has no
+// portable way to style entities, so they are not drawn differently.
+func (g *MermaidGenerator) writeForeignTables(sb *strings.Builder, tables []models.ForeignTable) {
+	for i := range tables {
+		fmt.Fprintf(sb, "    %%%% foreign table on server %s\n", tables[i].Server)
+		g.writeTableDefinition(sb, tables[i].Name, tables[i].Columns)
+	}
+}
+
+// GenerateDependencyGraph draws a flowchart from each relation to the views
+// and materialized views that read from it, so readers can see what a change to a base table affects.
+func (g *MermaidGenerator) GenerateDependencyGraph(schema *models.Schema) (string, error) {
+	var (
+		sb    strings.Builder
+		nodes []models.Dependency
+		edges []string
+	)
+
+	seen := make(map[string]bool)
+	addNode := func(node models.Dependency) string {
+		id := g.nodeID(node.Schema, node.Name)
+		if !seen[id] {
+			seen[id] = true
+
+			nodes = append(nodes, node)
+		}
+
+		return id
+	}
+
+	for i := range schema.Views {
+		view := &schema.Views[i]
+		viewID := addNode(models.Dependency{Schema: view.Schema, Name: view.Name, Kind: "view"})
+
+		for _, dep := range view.Dependencies {
+			edges = append(edges, fmt.Sprintf("    %s --> %s\n", addNode(dep), viewID))
+		}
+	}
+
+	for i := range schema.MaterializedViews {
+		view := &schema.MaterializedViews[i]
+		viewID := addNode(models.Dependency{Schema: view.Schema, Name: view.Name, Kind: "materialized view"})
+
+		for _, dep := range view.Dependencies {
+			edges = append(edges, fmt.Sprintf("    %s --> %s\n", addNode(dep), viewID))
+		}
+	}
+
+	sb.WriteString("flowchart LR\n")
+
+	for _, node := range nodes {
+		fmt.Fprintf(&sb, "    %s%s\n", g.nodeID(node.Schema, node.Name), g.nodeShape(node))
+	}
+
+	if len(edges) > 0 {
+		sb.WriteString("\n")
+	}
+
+	for _, edge := range edges {
+		sb.WriteString(edge)
+	}
+
+	return sb.String(), nil
+}
+
+// nodeID builds a flowchart node identifier; Mermaid ids cannot contain dots
+// or other punctuation that may appear in quoted PostgreSQL identifiers.
+func (g *MermaidGenerator) nodeID(schema, name string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' {
+			return r
+		}
+
+		return '_'
+	}, schema+"__"+name)
+}
+
+// nodeShape picks a flowchart shape per relation kind: rectangles for tables
+// rounded boxes for views and cylinders for materialized views, which store
+// their data.
+func (g *MermaidGenerator) nodeShape(node models.Dependency) string {
+	label := fmt.Sprintf("%q", node.Schema+"."+node.Name)
+
+	switch node.Kind {
+	case "view":
+		return "(" + label + ")"
+	case "materialized view":
+		return "[(" + label + ")]"
+	default:
+		return "[" + label + "]"
+	}
+}
+
 type relationship struct {
 	ParentTable string
 	ChildTable  string
@@ -57,7 +153,7 @@ func (g *MermaidGenerator) extractRelationships(tables []models.Table) []relatio
 			relationships = append(relationships, relationship{
 				ParentTable: referencedTable,
 				ChildTable:  tables[i].Name,
-				ForeignKey:  fk.SourceColumn,
+				ForeignKey:  strings.Join(fk.SourceColumns, ", "),
 			})
 		}
 	}
@@ -65,10 +161,10 @@ func (g *MermaidGenerator) extractRelationships(tables []models.Table) []relatio
 	return relationships
 }
 
-func (g *MermaidGenerator) writeTableDefinition(sb *strings.Builder, table *models.Table) {
-	fmt.Fprintf(sb, "    %s {\n", table.Name)
+func (g *MermaidGenerator) writeTableDefinition(sb *strings.Builder, name string, columns []models.Column) {
+	fmt.Fprintf(sb, "    %s {\n", name)
 
-	for _, col := range table.Columns {
+	for _, col := range columns {
 		// Normalize data type for Mermaid compatibility (single words only)
 		normalizedType := g.normalizeDataType(col.DataType)
 		columnDef := fmt.Sprintf("        %s %s", normalizedType, col.Name)
@@ -110,6 +206,12 @@ func (g *MermaidGenerator) normalizeDataType(dataType string) string {
 		return normalized
 	}
 
+	// Mermaid type names cannot contain dots, so drop the schema from
+	// user-defined types such as "public.mood"
+	if idx := strings.LastIndex(dataType, "."); idx >= 0 {
+		dataType = dataType[idx+1:]
+	}
+
 	// For types not in the map, remove spaces and use first word
 	parts := strings.Fields(dataType)
 	if len(parts) > 0 {
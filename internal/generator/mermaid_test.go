@@ -1,6 +1,7 @@
 package generator
 
 import (
+	"regexp"
 	"strings"
 	"testing"
 
@@ -67,11 +68,11 @@ func TestGenerateMermaidER(t *testing.T) {
 						},
 						ForeignKeys: []models.ForeignKey{
 							{
-								Name:             "fk_orders_user_id",
-								SourceTable:      "orders",
-								SourceColumn:     "user_id",
-								ReferencedTable:  "public.users",
-								ReferencedColumn: "id",
+								Name:              "fk_orders_user_id",
+								SourceTable:       "orders",
+								SourceColumns:     []string{"user_id"},
+								ReferencedTable:   "public.users",
+								ReferencedColumns: []string{"id"},
 							},
 						},
 					},
@@ -84,6 +85,43 @@ func TestGenerateMermaidER(t *testing.T) {
 				"orders {",
 			},
 		},
+		{
+			name: "composite foreign key is a single relationship",
+			schema: models.Schema{
+				Name: "test_db",
+				Tables: []models.Table{
+					{
+						Schema: "public",
+						Name:   "order_lines",
+						Columns: []models.Column{
+							{Name: "order_id", DataType: "integer", IsPrimaryKey: true},
+							{Name: "line_no", DataType: "integer", IsPrimaryKey: true},
+						},
+					},
+					{
+						Schema: "public",
+						Name:   "shipments",
+						Columns: []models.Column{
+							{Name: "id", DataType: "integer", IsPrimaryKey: true},
+							{Name: "order_id", DataType: "integer"},
+							{Name: "line_no", DataType: "integer"},
+						},
+						ForeignKeys: []models.ForeignKey{
+							{
+								Name:              "fk_shipments_order_line",
+								SourceTable:       "shipments",
+								SourceColumns:     []string{"order_id", "line_no"},
+								ReferencedTable:   "public.order_lines",
+								ReferencedColumns: []string{"order_id", "line_no"},
+							},
+						},
+					},
+				},
+			},
+			expectContains: []string{
+				"order_lines ||--o{ shipments : \"order_id, line_no\"",
+			},
+		},
 		{
 			name: "comprehensive constraints and syntax validation",
 			schema: models.Schema{
@@ -112,6 +150,24 @@ func TestGenerateMermaidER(t *testing.T) {
 				"        decimal price",
 			},
 		},
+		{
+			name: "schema-qualified user-defined types",
+			schema: models.Schema{
+				Name: "test_db",
+				Tables: []models.Table{
+					{
+						Schema: "public",
+						Name:   "people",
+						Columns: []models.Column{
+							{Name: "current_mood", DataType: "public.mood", TypeRef: "public.mood"},
+						},
+					},
+				},
+			},
+			expectContains: []string{
+				"mood current_mood",
+			},
+		},
 		{
 			name: "data types with spaces should be normalized",
 			schema: models.Schema{
@@ -140,6 +196,26 @@ func TestGenerateMermaidER(t *testing.T) {
 				"        float amount",
 			},
 		},
+		{
+			name: "foreign tables are marked apart",
+			schema: models.Schema{
+				Name: "test_db",
+				Tables: []models.Table{
+					{Schema: "public", Name: "customers", Columns: []models.Column{{Name: "id", DataType: "integer", IsPrimaryKey: true}}},
+				},
+				ForeignTables: []models.ForeignTable{
+					{Schema: "remote", Name: "invoices", Server: "sales", Columns: []models.Column{{Name: "id", DataType: "integer"}}},
+					{Schema: "remote", Name: "payments", Server: "sales"},
+				},
+			},
+			expectContains: []string{
+				"customers {",
+				"invoices {",
+				"payments {",
+				"    %% foreign table on server sales\n    invoices {",
+				"    %% foreign table on server sales\n    payments {",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -206,4 +282,70 @@ func validateMermaidSyntax(t *testing.T, mermaidOutput string) {
 	if tableOpenBraces != closeBraces {
 		t.Errorf("Unmatched table braces in Mermaid output: %d table opens, %d closes.\nOutput:\n%s", tableOpenBraces, closeBraces, mermaidOutput)
 	}
+
+	// Every line must be a statement erDiagram accepts; class and classDef
+	// statements, for instance, break rendering in many Mermaid versions.
+	for _, line := range lines {
+		if !erDiagramLine.MatchString(line) {
+			t.Errorf("Mermaid output contains a line erDiagram does not accept: %q\nOutput:\n%s", line, mermaidOutput)
+		}
+	}
+}
+
+var erDiagramLine = regexp.MustCompile(`^(erDiagram|` +
+	`\s*|` + // blank line
+	`\s*%%.*|` + // comment
+	`\s+\S+ \|\|--o\{ \S+ : ".*"|` + // relationship
+	`\s+\S+ \{|` + // entity opening
+	`\s+\S+ \S+( PK| UK)?|` + // attribute
+	`\s+\})$`)
+
+func TestGenerateDependencyGraph(t *testing.T) {
+	schema := models.Schema{
+		Name: "test_db",
+		Views: []models.View{
+			{
+				Schema: "public",
+				Name:   "order_totals",
+				Dependencies: []models.Dependency{
+					{Schema: "public", Name: "orders", Kind: "table"},
+					{Schema: "public", Name: "order_lines", Kind: "table"},
+				},
+			},
+			{
+				Schema: "reporting",
+				Name:   "top_customers",
+				Dependencies: []models.Dependency{
+					{Schema: "public", Name: "order_totals", Kind: "view"},
+				},
+			},
+		},
+	}
+
+	generator := NewMermaidGenerator()
+	output, err := generator.GenerateDependencyGraph(&schema)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectContains := []string{
+		"flowchart LR",
+		`public__order_totals("public.order_totals")`,
+		`public__orders["public.orders"]`,
+		`reporting__top_customers("reporting.top_customers")`,
+		"public__orders --> public__order_totals",
+		"public__order_lines --> public__order_totals",
+		"public__order_totals --> reporting__top_customers",
+	}
+
+	for _, expected := range expectContains {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected output to contain '%s', but it didn't.\nOutput:\n%s", expected, output)
+		}
+	}
+
+	if count := strings.Count(output, `public__order_totals("public.order_totals")`); count != 1 {
+		t.Errorf("expected view node to be declared once, got %d.\nOutput:\n%s", count, output)
+	}
 }
@@ -16,84 +16,432 @@ func NewJSONReporter() *JSONReporter {
 
 // JSONOutput represents the JSON structure for database documentation.
 type JSONOutput struct {
-	GeneratedAt   string             `json:"generated_at"`
-	DatabaseName  string             `json:"database_name"`
-	Summary       DatabaseSummary    `json:"summary"`
-	Extensions    []JSONExtension    `json:"extensions,omitempty"`
-	Tables        []JSONTable        `json:"tables"`
-	Relationships []JSONRelationship `json:"relationships,omitempty"`
+	GeneratedAt       string                 `json:"generated_at"`
+	Snapshot          *JSONSnapshot          `json:"snapshot,omitempty"`
+	Warnings          []JSONWarning          `json:"warnings,omitempty"`
+	DatabaseName      string                 `json:"database_name"`
+	Database          *JSONDatabase          `json:"database,omitempty"`
+	Summary           DatabaseSummary        `json:"summary"`
+	Extensions        []JSONExtension        `json:"extensions,omitempty"`
+	Tables            []JSONTable            `json:"tables"`
+	Views             []JSONView             `json:"views,omitempty"`
+	MaterializedViews []JSONMaterializedView `json:"materialized_views,omitempty"`
+	ForeignData       *JSONForeignData       `json:"foreign_data,omitempty"`
+	Sequences         []JSONSequence         `json:"sequences,omitempty"`
+	Types             []JSONType             `json:"types,omitempty"`
+	Routines          []JSONRoutine          `json:"routines,omitempty"`
+	Privileges        *JSONPrivileges        `json:"privileges,omitempty"`
+	DatabaseObjects   *JSONDatabaseObjects   `json:"database_objects,omitempty"`
+	Relationships     []JSONRelationship     `json:"relationships,omitempty"`
 }
 
 type DatabaseSummary struct {
 	TableCount int   `json:"table_count"`
 	TotalRows  int64 `json:"total_rows"`
+
+	TotalSizeBytes int64 `json:"total_size_bytes"`
 }
 
 type JSONTable struct {
-	Name        string           `json:"name"`
-	Schema      string           `json:"schema"`
-	RowCount    int64            `json:"row_count"`
-	Columns     []JSONColumn     `json:"columns"`
-	ForeignKeys []JSONForeignKey `json:"foreign_keys,omitempty"`
-	Indexes     []JSONIndex      `json:"indexes,omitempty"`
-	Triggers    []JSONTrigger    `json:"triggers,omitempty"`
+	Name               string           `json:"name"`
+	Schema             string           `json:"schema"`
+	Description        string           `json:"description,omitempty"`
+	RowCount           int64            `json:"row_count"`
+	RowCountMethod     string           `json:"row_count_method,omitempty"`
+	RowCountConfidence string           `json:"row_count_confidence,omitempty"`
+	Columns            []JSONColumn     `json:"columns"`
+	ForeignKeys        []JSONForeignKey `json:"foreign_keys,omitempty"`
+	Indexes            []JSONIndex      `json:"indexes,omitempty"`
+	Triggers           []JSONTrigger    `json:"triggers,omitempty"`
+	Constraints        []JSONConstraint `json:"constraints,omitempty"`
+
+	HeapSizeBytes    int64 `json:"heap_size_bytes"`
+	ToastSizeBytes   int64 `json:"toast_size_bytes"`
+	IndexesSizeBytes int64 `json:"indexes_size_bytes"`
+	TotalSizeBytes   int64 `json:"total_size_bytes"`
+
+	Stats *JSONTableStats `json:"stats,omitempty"`
+
+	PartitionStrategy string          `json:"partition_strategy,omitempty"`
+	PartitionKey      string          `json:"partition_key,omitempty"`
+	PartitionOf       string          `json:"partition_of,omitempty"`
+	PartitionBound    string          `json:"partition_bound,omitempty"`
+	Partitions        []JSONPartition `json:"partitions,omitempty"`
+
+	RowSecurity      bool         `json:"row_security"`
+	ForceRowSecurity bool         `json:"force_row_security"`
+	Policies         []JSONPolicy `json:"policies,omitempty"`
+
+	Publications []string `json:"publications,omitempty"`
+}
+
+// JSONDatabaseObjects holds objects that act across the database rather than
+// on a single table's definition.
+type JSONDatabaseObjects struct {
+	EventTriggers []JSONEventTrigger `json:"event_triggers,omitempty"`
+	Rules         []JSONRule         `json:"rules,omitempty"`
+	Publications  []JSONPublication  `json:"publications,omitempty"`
+}
+
+type JSONEventTrigger struct {
+	Name           string   `json:"name"`
+	Event          string   `json:"event"`
+	Tags           []string `json:"tags,omitempty"`
+	Function       string   `json:"function"`
+	FunctionSchema string   `json:"function_schema"`
+	Enabled        string   `json:"enabled"`
+	Owner          string   `json:"owner"`
+	Description    string   `json:"description,omitempty"`
+}
+
+type JSONRule struct {
+	Name       string `json:"name"`
+	Schema     string `json:"schema"`
+	Table      string `json:"table"`
+	Event      string `json:"event"`
+	IsInstead  bool   `json:"is_instead"`
+	Enabled    string `json:"enabled"`
+	Definition string `json:"definition"`
+}
+
+type JSONPublication struct {
+	Name       string   `json:"name"`
+	Owner      string   `json:"owner"`
+	AllTables  bool     `json:"all_tables"`
+	Operations []string `json:"operations"`
+	Tables     []string `json:"tables"`
+}
+
+type JSONTableStats struct {
+	SeqScans        int64      `json:"seq_scans"`
+	IndexScans      int64      `json:"index_scans"`
+	TuplesInserted  int64      `json:"tuples_inserted"`
+	TuplesUpdated   int64      `json:"tuples_updated"`
+	TuplesDeleted   int64      `json:"tuples_deleted"`
+	LiveTuples      int64      `json:"live_tuples"`
+	DeadTuples      int64      `json:"dead_tuples"`
+	LastVacuum      *time.Time `json:"last_vacuum,omitempty"`
+	LastAutovacuum  *time.Time `json:"last_autovacuum,omitempty"`
+	LastAnalyze     *time.Time `json:"last_analyze,omitempty"`
+	LastAutoanalyze *time.Time `json:"last_autoanalyze,omitempty"`
+	HeapBlocksHit   int64      `json:"heap_blocks_hit"`
+	HeapBlocksRead  int64      `json:"heap_blocks_read"`
+	IndexBlocksHit  int64      `json:"index_blocks_hit"`
+	IndexBlocksRead int64      `json:"index_blocks_read"`
+}
+
+type JSONPolicy struct {
+	Name         string   `json:"name"`
+	Command      string   `json:"command"`
+	IsPermissive bool     `json:"is_permissive"`
+	Roles        []string `json:"roles"`
+	Using        string   `json:"using,omitempty"`
+	WithCheck    string   `json:"with_check,omitempty"`
+}
+
+type JSONPartition struct {
+	Name              string          `json:"name"`
+	Schema            string          `json:"schema"`
+	Bound             string          `json:"bound"`
+	RowCount          int64           `json:"row_count"`
+	PartitionStrategy string          `json:"partition_strategy,omitempty"`
+	PartitionKey      string          `json:"partition_key,omitempty"`
+	Partitions        []JSONPartition `json:"partitions,omitempty"`
 }
 
 type JSONColumn struct {
 	Name         string  `json:"name"`
 	DataType     string  `json:"data_type"`
+	TypeRef      string  `json:"type_ref,omitempty"`
 	MaxLength    *int    `json:"max_length,omitempty"`
 	IsNullable   bool    `json:"is_nullable"`
 	IsPrimaryKey bool    `json:"is_primary_key"`
 	IsUnique     bool    `json:"is_unique"`
 	DefaultValue *string `json:"default_value,omitempty"`
+	Description  string  `json:"description,omitempty"`
+
+	FormattedType        string `json:"formatted_type"`
+	Precision            *int   `json:"precision,omitempty"`
+	Scale                *int   `json:"scale,omitempty"`
+	ArrayDimensions      int    `json:"array_dimensions,omitempty"`
+	Collation            string `json:"collation,omitempty"`
+	Identity             string `json:"identity,omitempty"`
+	Generated            string `json:"generated,omitempty"`
+	GenerationExpression string `json:"generation_expression,omitempty"`
 }
 
 type JSONForeignKey struct {
-	Name             string `json:"name"`
-	SourceTable      string `json:"source_table"`
-	SourceColumn     string `json:"source_column"`
-	ReferencedTable  string `json:"referenced_table"`
-	ReferencedColumn string `json:"referenced_column"`
+	Name              string   `json:"name"`
+	SourceTable       string   `json:"source_table"`
+	SourceColumns     []string `json:"source_columns"`
+	ReferencedTable   string   `json:"referenced_table"`
+	ReferencedColumns []string `json:"referenced_columns"`
+	OnDelete          string   `json:"on_delete,omitempty"`
+	OnUpdate          string   `json:"on_update,omitempty"`
+}
+
+type JSONConstraint struct {
+	Name         string   `json:"name"`
+	Type         string   `json:"type"`
+	Columns      []string `json:"columns,omitempty"`
+	Definition   string   `json:"definition"`
+	IsDeferrable bool     `json:"is_deferrable"`
+	IsDeferred   bool     `json:"is_deferred"`
+	IsValidated  bool     `json:"is_validated"`
 }
 
 type JSONIndex struct {
-	Name      string   `json:"name"`
-	Type      string   `json:"type"`
-	IsPrimary bool     `json:"is_primary"`
-	IsUnique  bool     `json:"is_unique"`
-	Columns   []string `json:"columns"`
-	Method    string   `json:"method"`
+	Name       string             `json:"name"`
+	Type       string             `json:"type"`
+	IsPrimary  bool               `json:"is_primary"`
+	IsUnique   bool               `json:"is_unique"`
+	Columns    []string           `json:"columns"`
+	Method     string             `json:"method"`
+	Definition string             `json:"definition,omitempty"`
+	KeyParts   []JSONIndexKeyPart `json:"key_parts,omitempty"`
+	Include    []string           `json:"include,omitempty"`
+	Predicate  string             `json:"predicate,omitempty"`
+	IsValid    bool               `json:"is_valid"`
+	SizeBytes  int64              `json:"size_bytes"`
+}
+
+type JSONIndexKeyPart struct {
+	Column     string `json:"column,omitempty"`
+	Expression string `json:"expression,omitempty"`
+	Descending bool   `json:"descending"`
+	NullsFirst bool   `json:"nulls_first"`
+	OpClass    string `json:"opclass,omitempty"`
 }
 
 type JSONTrigger struct {
-	Name        string `json:"name"`
-	Event       string `json:"event"`
-	Timing      string `json:"timing"`
-	Function    string `json:"function"`
-	Orientation string `json:"orientation"`
+	Name           string `json:"name"`
+	Event          string `json:"event"`
+	Timing         string `json:"timing"`
+	Function       string `json:"function"`
+	FunctionSchema string `json:"function_schema,omitempty"`
+	Orientation    string `json:"orientation"`
+
+	Definition   string   `json:"definition,omitempty"`
+	Condition    string   `json:"condition,omitempty"`
+	Columns      []string `json:"columns,omitempty"`
+	OldTable     string   `json:"old_table,omitempty"`
+	NewTable     string   `json:"new_table,omitempty"`
+	IsConstraint bool     `json:"is_constraint"`
+	Enabled      string   `json:"enabled,omitempty"`
+	ActionOrder  int      `json:"action_order,omitempty"`
 }
 
 type JSONExtension struct {
-	Name    string `json:"name"`
-	Version string `json:"version"`
-	Schema  string `json:"schema"`
+	Name           string `json:"name"`
+	Version        string `json:"version"`
+	Schema         string `json:"schema"`
+	DefaultVersion string `json:"default_version,omitempty"`
+	HasUpgrade     bool   `json:"has_upgrade"`
+}
+
+// JSONWarning records part of the schema that could not be read.
+type JSONWarning struct {
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+}
+
+// JSONSnapshot identifies the transaction snapshot the output was read from.
+type JSONSnapshot struct {
+	ID      string `json:"id,omitempty"`
+	TakenAt string `json:"taken_at"`
+}
+
+type JSONDatabase struct {
+	Name          string        `json:"name"`
+	Engine        string        `json:"engine"`
+	ServerVersion string        `json:"server_version"`
+	Owner         string        `json:"owner,omitempty"`
+	Encoding      string        `json:"encoding,omitempty"`
+	Collation     string        `json:"collation,omitempty"`
+	CType         string        `json:"ctype,omitempty"`
+	TimeZone      string        `json:"time_zone,omitempty"`
+	SizeBytes     int64         `json:"size_bytes"`
+	Settings      []JSONSetting `json:"settings,omitempty"`
+	Schemas       []JSONSchema  `json:"schemas,omitempty"`
+}
+
+type JSONSetting struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Source string `json:"source"`
+}
+
+type JSONSchema struct {
+	Name        string `json:"name"`
+	Owner       string `json:"owner,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+type JSONView struct {
+	Name         string           `json:"name"`
+	Schema       string           `json:"schema"`
+	Description  string           `json:"description,omitempty"`
+	Definition   string           `json:"definition,omitempty"`
+	Columns      []JSONColumn     `json:"columns,omitempty"`
+	Dependencies []JSONDependency `json:"dependencies,omitempty"`
+	Triggers     []JSONTrigger    `json:"triggers,omitempty"`
+}
+
+type JSONMaterializedView struct {
+	Name         string           `json:"name"`
+	Schema       string           `json:"schema"`
+	Description  string           `json:"description,omitempty"`
+	Definition   string           `json:"definition,omitempty"`
+	IsPopulated  bool             `json:"is_populated"`
+	SizeBytes    int64            `json:"size_bytes"`
+	Columns      []JSONColumn     `json:"columns,omitempty"`
+	Indexes      []JSONIndex      `json:"indexes,omitempty"`
+	Dependencies []JSONDependency `json:"dependencies,omitempty"`
+}
+
+type JSONForeignData struct {
+	Wrappers []JSONForeignDataWrapper `json:"wrappers,omitempty"`
+	Servers  []JSONForeignServer      `json:"servers,omitempty"`
+	Tables   []JSONForeignTable       `json:"tables,omitempty"`
+}
+
+type JSONForeignDataWrapper struct {
+	Name      string   `json:"name"`
+	Owner     string   `json:"owner"`
+	Handler   string   `json:"handler,omitempty"`
+	Validator string   `json:"validator,omitempty"`
+	Options   []string `json:"options,omitempty"`
+}
+
+type JSONForeignServer struct {
+	Name         string            `json:"name"`
+	Wrapper      string            `json:"wrapper"`
+	Type         string            `json:"type,omitempty"`
+	Version      string            `json:"version,omitempty"`
+	Owner        string            `json:"owner"`
+	Options      []string          `json:"options,omitempty"`
+	UserMappings []JSONUserMapping `json:"user_mappings,omitempty"`
+}
+
+type JSONUserMapping struct {
+	User    string   `json:"user"`
+	Options []string `json:"options,omitempty"`
+}
+
+type JSONForeignTable struct {
+	Name        string       `json:"name"`
+	Schema      string       `json:"schema"`
+	Description string       `json:"description,omitempty"`
+	Server      string       `json:"server"`
+	Options     []string     `json:"options,omitempty"`
+	Columns     []JSONColumn `json:"columns,omitempty"`
+}
+
+type JSONDependency struct {
+	Schema string `json:"schema"`
+	Name   string `json:"name"`
+	Kind   string `json:"kind"`
+}
+
+type JSONRoutine struct {
+	Name            string `json:"name"`
+	Schema          string `json:"schema"`
+	Kind            string `json:"kind"`
+	Arguments       string `json:"arguments"`
+	ReturnType      string `json:"return_type,omitempty"`
+	Language        string `json:"language"`
+	Volatility      string `json:"volatility"`
+	SecurityDefiner bool   `json:"security_definer"`
+	Owner           string `json:"owner,omitempty"`
+	Description     string `json:"description,omitempty"`
+	Source          string `json:"source,omitempty"`
+}
+
+// JSONPrivileges is the role-by-object privilege matrix. Each object maps a
+// role to the privileges it holds there.
+type JSONPrivileges struct {
+	Roles   []string               `json:"roles"`
+	Objects []JSONObjectPrivileges `json:"objects"`
+}
+
+type JSONObjectPrivileges struct {
+	ObjectType string                     `json:"object_type"`
+	Schema     string                     `json:"schema"`
+	Name       string                     `json:"name"`
+	Column     string                     `json:"column,omitempty"`
+	Grants     map[string][]JSONPrivilege `json:"grants"`
+}
+
+type JSONPrivilege struct {
+	Privilege   string `json:"privilege"`
+	Grantor     string `json:"grantor,omitempty"`
+	IsGrantable bool   `json:"is_grantable"`
+}
+
+type JSONSequence struct {
+	Name        string `json:"name"`
+	Schema      string `json:"schema"`
+	DataType    string `json:"data_type"`
+	StartValue  int64  `json:"start_value"`
+	MinValue    int64  `json:"min_value"`
+	MaxValue    int64  `json:"max_value"`
+	Increment   int64  `json:"increment"`
+	Description string `json:"description,omitempty"`
+
+	LastValue   *int64  `json:"last_value,omitempty"`
+	PercentUsed float64 `json:"percent_used"`
+	OwnerTable  string  `json:"owner_table,omitempty"`
+	OwnerColumn string  `json:"owner_column,omitempty"`
+	IsIdentity  bool    `json:"is_identity"`
+}
+
+type JSONType struct {
+	Name         string              `json:"name"`
+	Schema       string              `json:"schema"`
+	Kind         string              `json:"kind"`
+	Description  string              `json:"description,omitempty"`
+	Labels       []string            `json:"labels,omitempty"`
+	BaseType     string              `json:"base_type,omitempty"`
+	DefaultValue *string             `json:"default_value,omitempty"`
+	NotNull      bool                `json:"not_null,omitempty"`
+	Constraints  []string            `json:"constraints,omitempty"`
+	Attributes   []JSONTypeAttribute `json:"attributes,omitempty"`
+	Subtype      string              `json:"subtype,omitempty"`
+}
+
+type JSONTypeAttribute struct {
+	Name     string `json:"name"`
+	DataType string `json:"data_type"`
 }
 
 type JSONRelationship struct {
-	ParentTable string `json:"parent_table"`
-	ChildTable  string `json:"child_table"`
-	ForeignKey  string `json:"foreign_key"`
+	ParentTable   string   `json:"parent_table"`
+	ChildTable    string   `json:"child_table"`
+	ForeignKey    string   `json:"foreign_key"`
+	Constraint    string   `json:"constraint"`
+	ChildColumns  []string `json:"child_columns"`
+	ParentColumns []string `json:"parent_columns"`
 }
 
 func (r *JSONReporter) Generate(schema *models.Schema) (string, error) {
 	output := JSONOutput{
-		GeneratedAt:   time.Now().Format(time.RFC3339),
-		DatabaseName:  schema.Name,
-		Summary:       r.buildSummary(schema.Tables),
-		Extensions:    r.buildExtensions(schema.Extensions),
-		Tables:        r.buildTables(schema.Tables),
-		Relationships: r.buildRelationships(schema.Tables),
+		GeneratedAt:       time.Now().Format(time.RFC3339),
+		Snapshot:          r.buildSnapshot(schema.Snapshot),
+		Warnings:          r.buildWarnings(schema.Warnings),
+		DatabaseName:      schema.Name,
+		Database:          r.buildDatabase(schema),
+		Summary:           r.buildSummary(schema),
+		Extensions:        r.buildExtensions(schema.Extensions),
+		Tables:            r.buildTables(schema.Tables),
+		Views:             r.buildViews(schema.Views),
+		MaterializedViews: r.buildMaterializedViews(schema.MaterializedViews),
+		ForeignData:       r.buildForeignData(schema),
+		Sequences:         r.buildSequences(schema.Sequences),
+		Types:             r.buildTypes(schema.Types),
+		Routines:          r.buildRoutines(schema.Routines),
+		Privileges:        r.buildPrivileges(schema.Grants),
+		DatabaseObjects:   r.buildDatabaseObjects(schema),
+		Relationships:     r.buildRelationships(schema.Tables),
 	}
 
 	jsonBytes, err := json.MarshalIndent(output, "", "  ")
@@ -104,15 +452,61 @@ func (r *JSONReporter) Generate(schema *models.Schema) (string, error) {
 	return string(jsonBytes), nil
 }
 
-func (r *JSONReporter) buildSummary(tables []models.Table) DatabaseSummary {
-	var totalRows int64
-	for i := range tables {
-		totalRows += tables[i].RowCount
+func (r *JSONReporter) buildWarnings(warnings []models.Warning) []JSONWarning {
+	if len(warnings) == 0 {
+		return nil
+	}
+
+	result := make([]JSONWarning, len(warnings))
+	for i, warning := range warnings {
+		result[i] = JSONWarning{Reason: warning.Reason, Message: warning.Message}
+	}
+
+	return result
+}
+
+func (r *JSONReporter) buildSnapshot(snapshot *models.Snapshot) *JSONSnapshot {
+	if snapshot == nil {
+		return nil
+	}
+
+	return &JSONSnapshot{ID: snapshot.ID, TakenAt: snapshot.TakenAt.Format(time.RFC3339)}
+}
+
+func (r *JSONReporter) buildDatabase(schema *models.Schema) *JSONDatabase {
+	info := schema.Database
+	if info == nil {
+		return nil
+	}
+
+	database := &JSONDatabase{
+		Name:          info.Name,
+		Engine:        info.Engine,
+		ServerVersion: info.ServerVersion,
+		Owner:         info.Owner,
+		Encoding:      info.Encoding,
+		Collation:     info.Collation,
+		CType:         info.CType,
+		TimeZone:      info.TimeZone,
+		SizeBytes:     schema.SizeBytes,
+	}
+
+	for _, setting := range info.Settings {
+		database.Settings = append(database.Settings, JSONSetting(setting))
+	}
+
+	for _, s := range info.Schemas {
+		database.Schemas = append(database.Schemas, JSONSchema(s))
 	}
 
+	return database
+}
+
+func (r *JSONReporter) buildSummary(schema *models.Schema) DatabaseSummary {
 	return DatabaseSummary{
-		TableCount: len(tables),
-		TotalRows:  totalRows,
+		TableCount:     len(schema.Tables),
+		TotalRows:      totalRowCount(schema.Tables),
+		TotalSizeBytes: schema.SizeBytes,
 	}
 }
 
@@ -122,19 +516,111 @@ func (r *JSONReporter) buildTables(tables []models.Table) []JSONTable {
 	for i := range tables {
 		table := &tables[i]
 		jsonTables[i] = JSONTable{
-			Name:        table.Name,
-			Schema:      table.Schema,
-			RowCount:    table.RowCount,
-			Columns:     r.buildColumns(table.Columns),
-			ForeignKeys: r.buildForeignKeys(table.ForeignKeys),
-			Indexes:     r.buildIndexes(table.Indexes),
-			Triggers:    r.buildTriggers(table.Triggers),
+			Name:               table.Name,
+			Schema:             table.Schema,
+			Description:        table.Description,
+			RowCount:           table.RowCount,
+			RowCountMethod:     string(table.RowCountMethod),
+			RowCountConfidence: table.RowCountConfidence,
+			Columns:            r.buildColumns(table.Columns),
+			ForeignKeys:        r.buildForeignKeys(table.ForeignKeys),
+			Indexes:            r.buildIndexes(table.Indexes),
+			Triggers:           r.buildTriggers(table.Triggers),
+			Constraints:        r.buildConstraints(table.Constraints),
+
+			HeapSizeBytes:    table.HeapSizeBytes,
+			ToastSizeBytes:   table.ToastSizeBytes,
+			IndexesSizeBytes: table.IndexesSizeBytes,
+			TotalSizeBytes:   table.TotalSizeBytes,
+
+			Stats: r.buildTableStats(table.Stats),
+
+			PartitionStrategy: table.PartitionStrategy,
+			PartitionKey:      table.PartitionKey,
+			PartitionOf:       table.PartitionOf,
+			PartitionBound:    table.PartitionBound,
+			Partitions:        r.buildPartitions(table.Partitions),
+
+			RowSecurity:      table.RowSecurity,
+			ForceRowSecurity: table.ForceRowSecurity,
+			Policies:         r.buildPolicies(table.Policies),
+
+			Publications: table.Publications,
 		}
 	}
 
 	return jsonTables
 }
 
+func (r *JSONReporter) buildPolicies(policies []models.Policy) []JSONPolicy {
+	if len(policies) == 0 {
+		return nil
+	}
+
+	jsonPolicies := make([]JSONPolicy, len(policies))
+
+	for i := range policies {
+		policy := &policies[i]
+		jsonPolicies[i] = JSONPolicy{
+			Name:         policy.Name,
+			Command:      policy.Command,
+			IsPermissive: policy.IsPermissive,
+			Roles:        policy.Roles,
+			Using:        policy.Using,
+			WithCheck:    policy.WithCheck,
+		}
+	}
+
+	return jsonPolicies
+}
+
+func (r *JSONReporter) buildTableStats(stats *models.TableStats) *JSONTableStats {
+	if stats == nil {
+		return nil
+	}
+
+	return &JSONTableStats{
+		SeqScans:        stats.SeqScans,
+		IndexScans:      stats.IndexScans,
+		TuplesInserted:  stats.TuplesInserted,
+		TuplesUpdated:   stats.TuplesUpdated,
+		TuplesDeleted:   stats.TuplesDeleted,
+		LiveTuples:      stats.LiveTuples,
+		DeadTuples:      stats.DeadTuples,
+		LastVacuum:      stats.LastVacuum,
+		LastAutovacuum:  stats.LastAutovacuum,
+		LastAnalyze:     stats.LastAnalyze,
+		LastAutoanalyze: stats.LastAutoanalyze,
+		HeapBlocksHit:   stats.HeapBlocksHit,
+		HeapBlocksRead:  stats.HeapBlocksRead,
+		IndexBlocksHit:  stats.IndexBlocksHit,
+		IndexBlocksRead: stats.IndexBlocksRead,
+	}
+}
+
+func (r *JSONReporter) buildPartitions(partitions []models.Table) []JSONPartition {
+	if len(partitions) == 0 {
+		return nil
+	}
+
+	jsonPartitions := make([]JSONPartition, len(partitions))
+
+	for i := range partitions {
+		partition := &partitions[i]
+		jsonPartitions[i] = JSONPartition{
+			Name:              partition.Name,
+			Schema:            partition.Schema,
+			Bound:             partition.PartitionBound,
+			RowCount:          partition.RowCount,
+			PartitionStrategy: partition.PartitionStrategy,
+			PartitionKey:      partition.PartitionKey,
+			Partitions:        r.buildPartitions(partition.Partitions),
+		}
+	}
+
+	return jsonPartitions
+}
+
 func (r *JSONReporter) buildColumns(columns []models.Column) []JSONColumn {
 	jsonColumns := make([]JSONColumn, len(columns))
 
@@ -142,11 +628,22 @@ func (r *JSONReporter) buildColumns(columns []models.Column) []JSONColumn {
 		jsonColumns[i] = JSONColumn{
 			Name:         col.Name,
 			DataType:     col.DataType,
+			TypeRef:      col.TypeRef,
 			MaxLength:    col.MaxLength,
 			IsNullable:   col.IsNullable,
 			IsPrimaryKey: col.IsPrimaryKey,
 			IsUnique:     col.IsUnique,
 			DefaultValue: col.DefaultValue,
+			Description:  col.Description,
+
+			FormattedType:        formatColumnType(&col),
+			Precision:            col.Precision,
+			Scale:                col.Scale,
+			ArrayDimensions:      col.ArrayDimensions,
+			Collation:            col.Collation,
+			Identity:             col.Identity,
+			Generated:            col.Generated,
+			GenerationExpression: col.GenerationExpression,
 		}
 	}
 
@@ -162,17 +659,42 @@ func (r *JSONReporter) buildForeignKeys(foreignKeys []models.ForeignKey) []JSONF
 
 	for i, fk := range foreignKeys {
 		jsonForeignKeys[i] = JSONForeignKey{
-			Name:             fk.Name,
-			SourceTable:      fk.SourceTable,
-			SourceColumn:     fk.SourceColumn,
-			ReferencedTable:  fk.ReferencedTable,
-			ReferencedColumn: fk.ReferencedColumn,
+			Name:              fk.Name,
+			SourceTable:       fk.SourceTable,
+			SourceColumns:     fk.SourceColumns,
+			ReferencedTable:   fk.ReferencedTable,
+			ReferencedColumns: fk.ReferencedColumns,
+			OnDelete:          fk.OnDelete,
+			OnUpdate:          fk.OnUpdate,
 		}
 	}
 
 	return jsonForeignKeys
 }
 
+func (r *JSONReporter) buildConstraints(constraints []models.Constraint) []JSONConstraint {
+	if len(constraints) == 0 {
+		return nil
+	}
+
+	jsonConstraints := make([]JSONConstraint, len(constraints))
+
+	for i := range constraints {
+		constraint := &constraints[i]
+		jsonConstraints[i] = JSONConstraint{
+			Name:         constraint.Name,
+			Type:         constraint.Type,
+			Columns:      constraint.Columns,
+			Definition:   constraint.Definition,
+			IsDeferrable: constraint.IsDeferrable,
+			IsDeferred:   constraint.IsDeferred,
+			IsValidated:  constraint.IsValidated,
+		}
+	}
+
+	return jsonConstraints
+}
+
 func (r *JSONReporter) buildIndexes(indexes []models.Index) []JSONIndex {
 	if len(indexes) == 0 {
 		return nil
@@ -181,13 +703,30 @@ func (r *JSONReporter) buildIndexes(indexes []models.Index) []JSONIndex {
 	jsonIndexes := make([]JSONIndex, len(indexes))
 
 	for i, idx := range indexes {
+		var keyParts []JSONIndexKeyPart
+		for _, part := range idx.KeyParts {
+			keyParts = append(keyParts, JSONIndexKeyPart{
+				Column:     part.Column,
+				Expression: part.Expression,
+				Descending: part.Descending,
+				NullsFirst: part.NullsFirst,
+				OpClass:    part.OpClass,
+			})
+		}
+
 		jsonIndexes[i] = JSONIndex{
-			Name:      idx.Name,
-			Type:      idx.Type,
-			IsPrimary: idx.IsPrimary,
-			IsUnique:  idx.IsUnique,
-			Columns:   idx.Columns,
-			Method:    idx.Method,
+			Name:       idx.Name,
+			Type:       idx.Type,
+			IsPrimary:  idx.IsPrimary,
+			IsUnique:   idx.IsUnique,
+			Columns:    idx.Columns,
+			Method:     idx.Method,
+			Definition: idx.Definition,
+			KeyParts:   keyParts,
+			Include:    idx.Include,
+			Predicate:  idx.Predicate,
+			IsValid:    idx.IsValid,
+			SizeBytes:  idx.SizeBytes,
 		}
 	}
 
@@ -203,11 +742,21 @@ func (r *JSONReporter) buildTriggers(triggers []models.Trigger) []JSONTrigger {
 
 	for i, trigger := range triggers {
 		jsonTriggers[i] = JSONTrigger{
-			Name:        trigger.Name,
-			Event:       trigger.Event,
-			Timing:      trigger.Timing,
-			Function:    trigger.Function,
-			Orientation: trigger.Orientation,
+			Name:           trigger.Name,
+			Event:          trigger.Event,
+			Timing:         trigger.Timing,
+			Function:       trigger.Function,
+			FunctionSchema: trigger.FunctionSchema,
+			Orientation:    trigger.Orientation,
+
+			Definition:   trigger.Definition,
+			Condition:    trigger.Condition,
+			Columns:      trigger.Columns,
+			OldTable:     trigger.OldTable,
+			NewTable:     trigger.NewTable,
+			IsConstraint: trigger.IsConstraint,
+			Enabled:      trigger.Enabled,
+			ActionOrder:  trigger.ActionOrder,
 		}
 	}
 
@@ -223,15 +772,310 @@ func (r *JSONReporter) buildExtensions(extensions []models.Extension) []JSONExte
 
 	for i, ext := range extensions {
 		jsonExtensions[i] = JSONExtension{
-			Name:    ext.Name,
-			Version: ext.Version,
-			Schema:  ext.Schema,
+			Name:           ext.Name,
+			Version:        ext.Version,
+			Schema:         ext.Schema,
+			DefaultVersion: ext.DefaultVersion,
+			HasUpgrade:     ext.HasUpgrade(),
 		}
 	}
 
 	return jsonExtensions
 }
 
+func (r *JSONReporter) buildViews(views []models.View) []JSONView {
+	if len(views) == 0 {
+		return nil
+	}
+
+	jsonViews := make([]JSONView, len(views))
+
+	for i := range views {
+		view := &views[i]
+		jsonViews[i] = JSONView{
+			Name:         view.Name,
+			Schema:       view.Schema,
+			Description:  view.Description,
+			Definition:   view.Definition,
+			Columns:      r.buildColumns(view.Columns),
+			Dependencies: r.buildDependencies(view.Dependencies),
+			Triggers:     r.buildTriggers(view.Triggers),
+		}
+	}
+
+	return jsonViews
+}
+
+func (r *JSONReporter) buildMaterializedViews(views []models.MaterializedView) []JSONMaterializedView {
+	if len(views) == 0 {
+		return nil
+	}
+
+	jsonViews := make([]JSONMaterializedView, len(views))
+
+	for i := range views {
+		view := &views[i]
+		jsonViews[i] = JSONMaterializedView{
+			Name:         view.Name,
+			Schema:       view.Schema,
+			Description:  view.Description,
+			Definition:   view.Definition,
+			IsPopulated:  view.IsPopulated,
+			SizeBytes:    view.SizeBytes,
+			Columns:      r.buildColumns(view.Columns),
+			Indexes:      r.buildIndexes(view.Indexes),
+			Dependencies: r.buildDependencies(view.Dependencies),
+		}
+	}
+
+	return jsonViews
+}
+
+func (r *JSONReporter) buildForeignData(schema *models.Schema) *JSONForeignData {
+	if !hasForeignData(schema) {
+		return nil
+	}
+
+	foreignData := &JSONForeignData{}
+
+	for i := range schema.ForeignDataWrappers {
+		wrapper := &schema.ForeignDataWrappers[i]
+		foreignData.Wrappers = append(foreignData.Wrappers, JSONForeignDataWrapper{
+			Name:      wrapper.Name,
+			Owner:     wrapper.Owner,
+			Handler:   wrapper.Handler,
+			Validator: wrapper.Validator,
+			Options:   wrapper.Options,
+		})
+	}
+
+	for i := range schema.ForeignServers {
+		server := &schema.ForeignServers[i]
+
+		var mappings []JSONUserMapping
+		for _, mapping := range server.UserMappings {
+			mappings = append(mappings, JSONUserMapping{User: mapping.User, Options: mapping.Options})
+		}
+
+		foreignData.Servers = append(foreignData.Servers, JSONForeignServer{
+			Name:         server.Name,
+			Wrapper:      server.Wrapper,
+			Type:         server.Type,
+			Version:      server.Version,
+			Owner:        server.Owner,
+			Options:      server.Options,
+			UserMappings: mappings,
+		})
+	}
+
+	for i := range schema.ForeignTables {
+		table := &schema.ForeignTables[i]
+		foreignData.Tables = append(foreignData.Tables, JSONForeignTable{
+			Name:        table.Name,
+			Schema:      table.Schema,
+			Description: table.Description,
+			Server:      table.Server,
+			Options:     table.Options,
+			Columns:     r.buildColumns(table.Columns),
+		})
+	}
+
+	return foreignData
+}
+
+func (r *JSONReporter) buildDependencies(dependencies []models.Dependency) []JSONDependency {
+	if len(dependencies) == 0 {
+		return nil
+	}
+
+	jsonDependencies := make([]JSONDependency, len(dependencies))
+
+	for i, dep := range dependencies {
+		jsonDependencies[i] = JSONDependency{
+			Schema: dep.Schema,
+			Name:   dep.Name,
+			Kind:   dep.Kind,
+		}
+	}
+
+	return jsonDependencies
+}
+
+func (r *JSONReporter) buildRoutines(routines []models.Routine) []JSONRoutine {
+	if len(routines) == 0 {
+		return nil
+	}
+
+	jsonRoutines := make([]JSONRoutine, len(routines))
+
+	for i := range routines {
+		routine := &routines[i]
+		jsonRoutines[i] = JSONRoutine{
+			Name:            routine.Name,
+			Schema:          routine.Schema,
+			Kind:            routine.Kind,
+			Arguments:       routine.Arguments,
+			ReturnType:      routine.ReturnType,
+			Language:        routine.Language,
+			Volatility:      routine.Volatility,
+			SecurityDefiner: routine.SecurityDefiner,
+			Owner:           routine.Owner,
+			Description:     routine.Description,
+			Source:          routine.Source,
+		}
+	}
+
+	return jsonRoutines
+}
+
+func (r *JSONReporter) buildDatabaseObjects(schema *models.Schema) *JSONDatabaseObjects {
+	if !hasDatabaseObjects(schema) {
+		return nil
+	}
+
+	objects := &JSONDatabaseObjects{}
+
+	for i := range schema.EventTriggers {
+		trigger := &schema.EventTriggers[i]
+		objects.EventTriggers = append(objects.EventTriggers, JSONEventTrigger{
+			Name:           trigger.Name,
+			Event:          trigger.Event,
+			Tags:           trigger.Tags,
+			Function:       trigger.Function,
+			FunctionSchema: trigger.FunctionSchema,
+			Enabled:        trigger.Enabled,
+			Owner:          trigger.Owner,
+			Description:    trigger.Description,
+		})
+	}
+
+	for i := range schema.Rules {
+		rule := &schema.Rules[i]
+		objects.Rules = append(objects.Rules, JSONRule{
+			Name:       rule.Name,
+			Schema:     rule.Schema,
+			Table:      rule.Table,
+			Event:      rule.Event,
+			IsInstead:  rule.IsInstead,
+			Enabled:    rule.Enabled,
+			Definition: rule.Definition,
+		})
+	}
+
+	for i := range schema.Publications {
+		publication := &schema.Publications[i]
+		objects.Publications = append(objects.Publications, JSONPublication{
+			Name:       publication.Name,
+			Owner:      publication.Owner,
+			AllTables:  publication.AllTables,
+			Operations: publication.Operations,
+			Tables:     publication.Tables,
+		})
+	}
+
+	return objects
+}
+
+func (r *JSONReporter) buildPrivileges(grants []models.Grant) *JSONPrivileges {
+	if len(grants) == 0 {
+		return nil
+	}
+
+	matrix := buildPrivilegeMatrix(grants)
+	privileges := &JSONPrivileges{
+		Roles:   matrix.Roles,
+		Objects: make([]JSONObjectPrivileges, len(matrix.Objects)),
+	}
+
+	for i := range matrix.Objects {
+		object := &matrix.Objects[i]
+		jsonGrants := make(map[string][]JSONPrivilege, len(object.Grants))
+
+		for role, roleGrants := range object.Grants {
+			for _, grant := range roleGrants {
+				jsonGrants[role] = append(jsonGrants[role], JSONPrivilege{
+					Privilege:   grant.Privilege,
+					Grantor:     grant.Grantor,
+					IsGrantable: grant.IsGrantable,
+				})
+			}
+		}
+
+		privileges.Objects[i] = JSONObjectPrivileges{
+			ObjectType: object.ObjectType,
+			Schema:     object.Schema,
+			Name:       object.Object,
+			Column:     object.Column,
+			Grants:     jsonGrants,
+		}
+	}
+
+	return privileges
+}
+
+func (r *JSONReporter) buildSequences(sequences []models.Sequence) []JSONSequence {
+	if len(sequences) == 0 {
+		return nil
+	}
+
+	jsonSequences := make([]JSONSequence, len(sequences))
+
+	for i := range sequences {
+		seq := &sequences[i]
+		jsonSequences[i] = JSONSequence{
+			Name:        seq.Name,
+			Schema:      seq.Schema,
+			DataType:    seq.DataType,
+			StartValue:  seq.StartValue,
+			MinValue:    seq.MinValue,
+			MaxValue:    seq.MaxValue,
+			Increment:   seq.Increment,
+			Description: seq.Description,
+
+			LastValue:   seq.LastValue,
+			PercentUsed: seq.PercentUsed,
+			OwnerTable:  seq.OwnerTable,
+			OwnerColumn: seq.OwnerColumn,
+			IsIdentity:  seq.IsIdentity,
+		}
+	}
+
+	return jsonSequences
+}
+
+func (r *JSONReporter) buildTypes(types []models.Type) []JSONType {
+	if len(types) == 0 {
+		return nil
+	}
+
+	jsonTypes := make([]JSONType, len(types))
+
+	for i := range types {
+		typ := &types[i]
+
+		var attributes []JSONTypeAttribute
+		for _, attr := range typ.Attributes {
+			attributes = append(attributes, JSONTypeAttribute{Name: attr.Name, DataType: attr.DataType})
+		}
+
+		jsonTypes[i] = JSONType{
+			Name:         typ.Name,
+			Schema:       typ.Schema,
+			Kind:         typ.Kind,
+			Description:  typ.Description,
+			Labels:       typ.Labels,
+			BaseType:     typ.BaseType,
+			DefaultValue: typ.DefaultValue,
+			NotNull:      typ.NotNull,
+			Constraints:  typ.Constraints,
+			Attributes:   attributes,
+			Subtype:      typ.Subtype,
+		}
+	}
+
+	return jsonTypes
+}
+
 func (r *JSONReporter) buildRelationships(tables []models.Table) []JSONRelationship {
 	var relationships []JSONRelationship
 
@@ -247,9 +1091,12 @@ func (r *JSONReporter) buildRelationships(tables []models.Table) []JSONRelations
 			}
 
 			relationships = append(relationships, JSONRelationship{
-				ParentTable: referencedTable,
-				ChildTable:  tables[i].Name,
-				ForeignKey:  fk.SourceColumn,
+				ParentTable:   referencedTable,
+				ChildTable:    tables[i].Name,
+				ForeignKey:    strings.Join(fk.SourceColumns, ","),
+				Constraint:    fk.Name,
+				ChildColumns:  fk.SourceColumns,
+				ParentColumns: fk.ReferencedColumns,
 			})
 		}
 	}
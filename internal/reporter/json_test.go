@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/orchard9/pg-goer/pkg/models"
 )
@@ -41,11 +42,11 @@ func TestJSONReporter_Generate(t *testing.T) {
 						},
 						ForeignKeys: []models.ForeignKey{
 							{
-								Name:             "fk_posts_user_id",
-								SourceTable:      "posts",
-								SourceColumn:     "user_id",
-								ReferencedTable:  "public.users",
-								ReferencedColumn: "id",
+								Name:              "fk_posts_user_id",
+								SourceTable:       "posts",
+								SourceColumns:     []string{"user_id"},
+								ReferencedTable:   "public.users",
+								ReferencedColumns: []string{"id"},
 							},
 						},
 					},
@@ -70,6 +71,511 @@ func TestJSONReporter_Generate(t *testing.T) {
 				"relationships",
 			},
 		},
+		{
+			name: "table with constraints",
+			schema: models.Schema{
+				Name: "constraint_db",
+				Tables: []models.Table{
+					{
+						Schema: "public",
+						Name:   "products",
+						Columns: []models.Column{
+							{Name: "price", DataType: "numeric", IsNullable: false},
+						},
+						Constraints: []models.Constraint{
+							{
+								Name:        "products_price_check",
+								Type:        "CHECK",
+								Columns:     []string{"price"},
+								Definition:  "CHECK (price > 0::numeric)",
+								IsValidated: false,
+							},
+						},
+					},
+				},
+			},
+			expectContains: []string{
+				`"constraints"`,
+				`"type": "CHECK"`,
+				`"definition": "CHECK (price \u003e 0::numeric)"`,
+				`"is_validated": false`,
+			},
+			expectFields: []string{
+				"tables",
+			},
+		},
+		{
+			name: "descriptions from comments",
+			schema: models.Schema{
+				Name: "comment_db",
+				Tables: []models.Table{
+					{
+						Schema:      "public",
+						Name:        "customers",
+						Description: "Paying customers",
+						Columns: []models.Column{
+							{Name: "id", DataType: "integer", IsPrimaryKey: true, Description: "Billing account id"},
+						},
+					},
+				},
+				Views: []models.View{
+					{Schema: "public", Name: "active_customers", Description: "Open contracts only"},
+				},
+				Sequences: []models.Sequence{
+					{Schema: "public", Name: "invoice_no_seq", DataType: "bigint", Description: "Invoice numbering"},
+				},
+			},
+			expectContains: []string{
+				`"description": "Paying customers"`,
+				`"description": "Billing account id"`,
+				`"description": "Open contracts only"`,
+				`"description": "Invoice numbering"`,
+			},
+			expectFields: []string{
+				"tables",
+				"views",
+				"sequences",
+			},
+		},
+		{
+			name: "partitioned table",
+			schema: models.Schema{
+				Name: "partition_db",
+				Tables: []models.Table{
+					{
+						Schema:            "public",
+						Name:              "events",
+						PartitionStrategy: "RANGE",
+						PartitionKey:      "created_at",
+						RowCount:          12,
+						Partitions: []models.Table{
+							{Schema: "public", Name: "events_2024", PartitionBound: "FOR VALUES FROM ('2024-01-01') TO ('2025-01-01')", RowCount: 12},
+						},
+					},
+				},
+			},
+			expectContains: []string{
+				`"partition_strategy": "RANGE"`,
+				`"partition_key": "created_at"`,
+				`"partitions"`,
+				`"name": "events_2024"`,
+				`"total_rows": 12`,
+			},
+			expectFields: []string{
+				"tables",
+			},
+		},
+		{
+			name: "user-defined types",
+			schema: models.Schema{
+				Name: "type_db",
+				Tables: []models.Table{
+					{
+						Schema:  "public",
+						Name:    "people",
+						Columns: []models.Column{{Name: "current_mood", DataType: "public.mood", TypeRef: "public.mood"}},
+					},
+				},
+				Types: []models.Type{
+					{Schema: "public", Name: "mood", Kind: "enum", Labels: []string{"sad", "happy"}},
+					{Schema: "public", Name: "price_range", Kind: "range", Subtype: "numeric"},
+				},
+			},
+			expectContains: []string{
+				`"type_ref": "public.mood"`,
+				`"kind": "enum"`,
+				`"sad"`,
+				`"subtype": "numeric"`,
+			},
+			expectFields: []string{
+				"tables",
+				"types",
+			},
+		},
+		{
+			name: "routines",
+			schema: models.Schema{
+				Name: "routine_db",
+				Tables: []models.Table{
+					{
+						Schema: "public",
+						Name:   "orders",
+						Triggers: []models.Trigger{
+							{Name: "orders_touch", Event: "UPDATE", Timing: "BEFORE", Function: "touch_updated_at", FunctionSchema: "public", Orientation: "ROW"},
+						},
+					},
+				},
+				Routines: []models.Routine{
+					{Schema: "public", Name: "touch_updated_at", Kind: "function", ReturnType: "trigger", Language: "plpgsql", Volatility: "VOLATILE", SecurityDefiner: true},
+				},
+			},
+			expectContains: []string{
+				`"function_schema": "public"`,
+				`"return_type": "trigger"`,
+				`"language": "plpgsql"`,
+				`"security_definer": true`,
+			},
+			expectFields: []string{
+				"tables",
+				"routines",
+			},
+		},
+		{
+			name: "row-level security",
+			schema: models.Schema{
+				Name: "rls_db",
+				Tables: []models.Table{
+					{
+						Schema:      "public",
+						Name:        "documents",
+						RowSecurity: true,
+						Policies: []models.Policy{
+							{Name: "tenant_isolation", Command: "ALL", IsPermissive: true, Roles: []string{"app_user"}, Using: "(tenant_id = 1)"},
+						},
+					},
+				},
+			},
+			expectContains: []string{
+				`"row_security": true`,
+				`"force_row_security": false`,
+				`"name": "tenant_isolation"`,
+				`"is_permissive": true`,
+				`"using": "(tenant_id = 1)"`,
+			},
+			expectFields: []string{
+				"tables",
+			},
+		},
+		{
+			name: "privilege matrix",
+			schema: models.Schema{
+				Name: "grant_db",
+				Tables: []models.Table{
+					{Schema: "public", Name: "users"},
+				},
+				Grants: []models.Grant{
+					{ObjectType: "table", Schema: "public", Object: "users", Grantee: "app", Grantor: "postgres", Privilege: "SELECT", IsGrantable: true},
+				},
+			},
+			expectContains: []string{
+				`"roles": [`,
+				`"object_type": "table"`,
+				`"app": [`,
+				`"privilege": "SELECT"`,
+				`"grantor": "postgres"`,
+				`"is_grantable": true`,
+			},
+			expectFields: []string{
+				"tables",
+				"privileges",
+			},
+		},
+		{
+			name: "index metadata",
+			schema: models.Schema{
+				Name: "index_db",
+				Tables: []models.Table{
+					{
+						Schema: "public",
+						Name:   "accounts",
+						Indexes: []models.Index{
+							{
+								Name:       "accounts_lower_email_idx",
+								Type:       "UNIQUE",
+								Columns:    []string{"lower(email)"},
+								Method:     "btree",
+								Definition: "CREATE UNIQUE INDEX accounts_lower_email_idx ON public.accounts USING btree (lower(email)) WHERE (deleted_at IS NULL)",
+								KeyParts:   []models.IndexKeyPart{{Expression: "lower(email)", Descending: true}},
+								Include:    []string{"id"},
+								Predicate:  "deleted_at IS NULL",
+							},
+						},
+					},
+				},
+			},
+			expectContains: []string{
+				`"definition": "CREATE UNIQUE INDEX accounts_lower_email_idx`,
+				`"expression": "lower(email)"`,
+				`"descending": true`,
+				`"include": [`,
+				`"predicate": "deleted_at IS NULL"`,
+				`"is_valid": false`,
+			},
+			expectFields: []string{
+				"tables",
+			},
+		},
+		{
+			name: "row count method",
+			schema: models.Schema{
+				Name: "count_db",
+				Tables: []models.Table{
+					{Schema: "public", Name: "events", RowCount: 42, RowCountMethod: models.RowCountEstimate, RowCountConfidence: models.ConfidenceLow},
+				},
+			},
+			expectContains: []string{
+				`"row_count": 42`,
+				`"row_count_method": "estimate"`,
+				`"row_count_confidence": "low"`,
+			},
+			expectFields: []string{
+				"tables",
+			},
+		},
+		{
+			name: "column type modifiers",
+			schema: models.Schema{
+				Name: "types_db",
+				Tables: []models.Table{
+					{
+						Schema: "public",
+						Name:   "invoices",
+						Columns: []models.Column{
+							{Name: "id", DataType: "bigint", Identity: models.IdentityByDefault},
+							{Name: "amount", DataType: "numeric", Precision: intPtr(12), Scale: intPtr(2), Collation: "C"},
+							{Name: "total", DataType: "numeric", Generated: "STORED", GenerationExpression: "amount * 2"},
+						},
+					},
+				},
+			},
+			expectContains: []string{
+				`"identity": "BY DEFAULT"`,
+				`"formatted_type": "numeric(12,2) COLLATE \"C\""`,
+				`"precision": 12`,
+				`"scale": 2`,
+				`"collation": "C"`,
+				`"generated": "STORED"`,
+				`"generation_expression": "amount * 2"`,
+			},
+			expectFields: []string{
+				"tables",
+			},
+		},
+		{
+			name: "trigger details",
+			schema: models.Schema{
+				Name: "trigger_db",
+				Tables: []models.Table{
+					{
+						Schema: "public",
+						Name:   "orders",
+						Triggers: []models.Trigger{
+							{
+								Name: "orders_audit", Event: "UPDATE", Timing: "AFTER", Function: "audit", Orientation: "STATEMENT",
+								Columns: []string{"status"}, Condition: "true", OldTable: "old_rows", NewTable: "new_rows",
+								IsConstraint: true, Enabled: models.TriggerDisabled, ActionOrder: 1,
+								Definition: "CREATE CONSTRAINT TRIGGER orders_audit AFTER UPDATE OF status ON public.orders",
+							},
+						},
+					},
+				},
+				Views: []models.View{
+					{
+						Schema: "public",
+						Name:   "order_summary",
+						Triggers: []models.Trigger{
+							{Name: "order_summary_insert", Event: "INSERT", Timing: "INSTEAD OF", Function: "insert_order", Orientation: "ROW"},
+						},
+					},
+				},
+			},
+			expectContains: []string{
+				`"definition": "CREATE CONSTRAINT TRIGGER orders_audit AFTER UPDATE OF status ON public.orders"`,
+				`"condition": "true"`,
+				`"columns": [`,
+				`"old_table": "old_rows"`,
+				`"new_table": "new_rows"`,
+				`"is_constraint": true`,
+				`"enabled": "DISABLED"`,
+				`"action_order": 1`,
+				`"timing": "INSTEAD OF"`,
+			},
+			expectFields: []string{
+				"tables",
+				"views",
+			},
+		},
+		{
+			name: "database-level objects",
+			schema: models.Schema{
+				Name: "objects_db",
+				Tables: []models.Table{
+					{Schema: "public", Name: "orders", Publications: []string{"cdc"}},
+				},
+				EventTriggers: []models.EventTrigger{
+					{Name: "audit_ddl", Event: "ddl_command_end", Tags: []string{"CREATE TABLE", "ALTER TABLE"}, Function: "log_ddl", FunctionSchema: "audit", Enabled: models.TriggerEnabled, Owner: "postgres"},
+					{Name: "block_drops", Event: "sql_drop", Function: "deny_drop", FunctionSchema: "audit", Enabled: models.TriggerDisabled, Owner: "postgres"},
+				},
+				Rules: []models.Rule{
+					{Schema: "public", Table: "orders", Name: "orders_no_delete", Event: "DELETE", IsInstead: true, Enabled: models.TriggerEnabled,
+						Definition: "CREATE RULE orders_no_delete AS ON DELETE TO public.orders DO INSTEAD NOTHING;"},
+				},
+				Publications: []models.Publication{
+					{Name: "cdc", Owner: "postgres", Operations: []string{"INSERT", "UPDATE", "DELETE"}, Tables: []string{"public.orders"}},
+					{Name: "everything", Owner: "postgres", AllTables: true, Operations: []string{"INSERT", "UPDATE", "DELETE", "TRUNCATE"}, Tables: []string{"public.orders"}},
+				},
+			},
+			expectContains: []string{
+				`"database_objects": {`,
+				`"event_triggers": [`,
+				`"event": "ddl_command_end"`,
+				`"rules": [`,
+				`"is_instead": true`,
+				`"publications": [`,
+				`"all_tables": true`,
+				`"operations": [`,
+			},
+			expectFields: []string{
+				"tables",
+				"database_objects",
+			},
+		},
+		{
+			name: "foreign data",
+			schema: models.Schema{
+				Name: "fdw_db",
+				ForeignDataWrappers: []models.ForeignDataWrapper{
+					{Name: "postgres_fdw", Owner: "postgres", Handler: "postgres_fdw_handler", Validator: "postgres_fdw_validator"},
+				},
+				ForeignServers: []models.ForeignServer{
+					{
+						Name: "sales", Wrapper: "postgres_fdw", Owner: "postgres",
+						Options:      []string{"host=sales.internal", "dbname=sales"},
+						UserMappings: []models.UserMapping{{User: "reporter", Options: []string{"user", "password"}}},
+					},
+				},
+				ForeignTables: []models.ForeignTable{
+					{
+						Schema: "remote", Name: "invoices", Server: "sales", Description: "Invoices from the sales database",
+						Options: []string{"schema_name=public", "table_name=invoices"},
+						Columns: []models.Column{{Name: "id", DataType: "integer"}, {Name: "total", DataType: "numeric", IsNullable: true}},
+					},
+				},
+			},
+			expectContains: []string{
+				`"foreign_data": {`,
+				`"wrappers": [`,
+				`"handler": "postgres_fdw_handler"`,
+				`"user_mappings": [`,
+				`"server": "sales"`,
+				`"table_name=invoices"`,
+			},
+			expectFields: []string{
+				"foreign_data",
+			},
+		},
+		{
+			name: "database overview",
+			schema: models.Schema{
+				Name: "shop",
+				Database: &models.DatabaseInfo{
+					Engine: "PostgreSQL", ServerVersion: "16.2", Name: "shop", Owner: "app",
+					Encoding: "UTF8", Collation: "en_US.UTF-8", CType: "en_US.UTF-8", TimeZone: "UTC",
+					Settings: []models.Setting{{Name: "shared_buffers", Value: "1024 MB", Source: "configuration file"}},
+					Schemas:  []models.SchemaInfo{{Name: "public", Owner: "app", Description: "standard public schema"}},
+				},
+				Extensions: []models.Extension{
+					{Name: "pgcrypto", Version: "1.3", Schema: "public", DefaultVersion: "1.3"},
+					{Name: "postgis", Version: "3.3.2", Schema: "public", DefaultVersion: "3.4.0"},
+				},
+				SizeBytes: 1048576,
+			},
+			expectContains: []string{
+				`"database": {`,
+				`"engine": "PostgreSQL"`,
+				`"server_version": "16.2"`,
+				`"ctype": "en_US.UTF-8"`,
+				`"size_bytes": 1048576`,
+				`"source": "configuration file"`,
+				`"owner": "app"`,
+				`"default_version": "3.4.0"`,
+				`"has_upgrade": true`,
+			},
+			expectFields: []string{
+				"database",
+				"extensions",
+			},
+		},
+		{
+			name: "sequence usage and ownership",
+			schema: models.Schema{
+				Name: "seq_db",
+				Sequences: []models.Sequence{
+					{Schema: "public", Name: "orders_id_seq", DataType: "integer", MaxValue: 2147483647,
+						LastValue: int64Ptr(1825361100), PercentUsed: 85, OwnerTable: "public.orders", OwnerColumn: "id", IsIdentity: true},
+				},
+			},
+			expectContains: []string{
+				`"last_value": 1825361100`,
+				`"percent_used": 85`,
+				`"owner_table": "public.orders"`,
+				`"owner_column": "id"`,
+				`"is_identity": true`,
+			},
+			expectFields: []string{
+				"sequences",
+			},
+		},
+		{
+			name: "activity statistics",
+			schema: models.Schema{
+				Name: "stats_db",
+				Tables: []models.Table{
+					{
+						Schema: "public",
+						Name:   "events",
+						Stats: &models.TableStats{
+							SeqScans:      3,
+							DeadTuples:    60,
+							LastAnalyze:   timePtr(time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)),
+							HeapBlocksHit: 990,
+						},
+					},
+				},
+			},
+			expectContains: []string{
+				`"stats": {`,
+				`"seq_scans": 3`,
+				`"dead_tuples": 60`,
+				`"last_analyze": "2024-03-01T12:30:00Z"`,
+				`"heap_blocks_hit": 990`,
+			},
+			expectFields: []string{
+				"tables",
+			},
+		},
+		{
+			name: "storage sizes",
+			schema: models.Schema{
+				Name:      "size_db",
+				SizeBytes: 1048576,
+				Tables: []models.Table{
+					{
+						Schema:           "public",
+						Name:             "documents",
+						HeapSizeBytes:    8192,
+						ToastSizeBytes:   16384,
+						IndexesSizeBytes: 32768,
+						TotalSizeBytes:   57344,
+						Indexes: []models.Index{
+							{Name: "documents_pkey", Columns: []string{"id"}, SizeBytes: 32768},
+						},
+					},
+				},
+			},
+			expectContains: []string{
+				`"total_size_bytes": 1048576`,
+				`"heap_size_bytes": 8192`,
+				`"toast_size_bytes": 16384`,
+				`"indexes_size_bytes": 32768`,
+				`"total_size_bytes": 57344`,
+				`"size_bytes": 32768`,
+			},
+			expectFields: []string{
+				"summary",
+				"tables",
+			},
+		},
 		{
 			name: "schema with no relationships",
 			schema: models.Schema{
@@ -99,6 +605,40 @@ func TestJSONReporter_Generate(t *testing.T) {
 				"tables",
 			},
 		},
+		{
+			name: "warnings",
+			schema: models.Schema{
+				Name: "shop",
+				Warnings: []models.Warning{
+					{Reason: models.WarningPermissionDenied, Message: "failed to get table statistics: pq: permission denied for table pg_stat_user_tables"},
+				},
+			},
+			expectContains: []string{
+				`"reason": "permission denied"`,
+				`"message": "failed to get table statistics: pq: permission denied for table pg_stat_user_tables"`,
+			},
+			expectFields: []string{
+				"warnings",
+			},
+		},
+		{
+			name: "snapshot",
+			schema: models.Schema{
+				Name:     "shop",
+				Snapshot: &models.Snapshot{ID: "00000003-0000001B-1", TakenAt: time.Date(2026, 3, 14, 9, 30, 0, 0, time.UTC)},
+				Tables: []models.Table{
+					{Schema: "public", Name: "users", Columns: []models.Column{{Name: "id", DataType: "integer"}}},
+				},
+			},
+			expectContains: []string{
+				`"id": "00000003-0000001B-1"`,
+				`"taken_at": "2026-03-14T09:30:00Z"`,
+			},
+			expectFields: []string{
+				"generated_at",
+				"snapshot",
+			},
+		},
 	}
 
 	for _, tt := range tests {
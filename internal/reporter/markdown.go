@@ -2,6 +2,7 @@ package reporter
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -9,37 +10,96 @@ import (
 	"github.com/orchard9/pg-goer/pkg/models"
 )
 
-type MarkdownReporter struct{}
+// Default sequence usage thresholds, in percent of the sequence's range.
+const (
+	DefaultSequenceWarning  = 50.0
+	DefaultSequenceCritical = 80.0
+)
+
+type MarkdownReporter struct {
+	sequenceWarning  float64
+	sequenceCritical float64
+}
 
 func NewMarkdownReporter() *MarkdownReporter {
-	return &MarkdownReporter{}
+	return &MarkdownReporter{
+		sequenceWarning:  DefaultSequenceWarning,
+		sequenceCritical: DefaultSequenceCritical,
+	}
+}
+
+// SetSequenceThresholds sets the percent used at which sequences are flagged
+// as a warning or as critical.
+func (r *MarkdownReporter) SetSequenceThresholds(warning, critical float64) {
+	r.sequenceWarning = warning
+	r.sequenceCritical = critical
 }
 
 func (r *MarkdownReporter) Generate(schema *models.Schema) (string, error) {
 	var sb strings.Builder
 
-	sb.WriteString("# PostgreSQL Database Documentation\n\n")
+	fmt.Fprintf(&sb, "# %s Database Documentation\n\n", databaseEngine(schema))
 	sb.WriteString(fmt.Sprintf("Generated on: %s\n\n", time.Now().Format("2006-01-02 15:04:05")))
 
-	if len(schema.Tables) == 0 {
-		sb.WriteString("No tables found in the database.\n")
-		return sb.String(), nil
+	if schema.Snapshot != nil {
+		writeSnapshot(&sb, schema.Snapshot)
+	}
+
+	if schema.Database != nil {
+		r.writeDatabaseOverview(&sb, schema)
+	}
+
+	if len(schema.Warnings) > 0 {
+		writeGaps(&sb, schema.Warnings)
 	}
 
 	// Generate Table of Contents
-	r.writeTableOfContents(&sb, schema.Tables, schema.Views, schema.Sequences, schema.Extensions)
+	r.writeTableOfContents(&sb, schema)
+
+	anchors := r.objectAnchors(schema)
 
 	// Generate Database Summary
-	r.writeDatabaseSummary(&sb, schema.Tables)
+	r.writeDatabaseSummary(&sb, schema)
 
 	// Generate Extensions section if any exist
 	if len(schema.Extensions) > 0 {
 		r.writeExtensions(&sb, schema.Extensions)
 	}
 
+	// Generate Types section if any exist
+	if len(schema.Types) > 0 {
+		r.writeTypes(&sb, schema.Types)
+	}
+
 	// Generate Views section if any exist
 	if len(schema.Views) > 0 {
-		r.writeViews(&sb, schema.Views)
+		r.writeViews(&sb, schema.Views, anchors)
+	}
+
+	// Generate Materialized Views section if any exist
+	if len(schema.MaterializedViews) > 0 {
+		r.writeMaterializedViews(&sb, schema.MaterializedViews)
+	}
+
+	// Generate Foreign Data section if any wrappers, servers or tables exist
+	if hasForeignData(schema) {
+		r.writeForeignData(&sb, schema)
+	}
+
+	// Generate view dependency flowchart if any view reads from another relation
+	if r.hasViewDependencies(schema) {
+		sb.WriteString("## View Dependencies\n\n")
+
+		mermaidGen := generator.NewMermaidGenerator()
+		diagram, err := mermaidGen.GenerateDependencyGraph(schema)
+
+		if err != nil {
+			return "", fmt.Errorf("failed to generate view dependency diagram: %w", err)
+		}
+
+		sb.WriteString("```mermaid\n")
+		sb.WriteString(diagram)
+		sb.WriteString("```\n\n")
 	}
 
 	// Generate Sequences section if any exist
@@ -47,8 +107,23 @@ func (r *MarkdownReporter) Generate(schema *models.Schema) (string, error) {
 		r.writeSequences(&sb, schema.Sequences)
 	}
 
+	// Generate Functions section if any exist
+	if len(schema.Routines) > 0 {
+		r.writeRoutines(&sb, schema.Routines)
+	}
+
+	// Generate Privileges matrix if any grants were collected
+	if len(schema.Grants) > 0 {
+		r.writePrivileges(&sb, schema.Grants)
+	}
+
+	// Generate Database-level Objects section if any exist
+	if hasDatabaseObjects(schema) {
+		r.writeDatabaseObjects(&sb, schema, anchors)
+	}
+
 	// Generate Mermaid ER diagram if there are relationships
-	if r.hasRelationships(schema.Tables) {
+	if r.hasERDiagram(schema) {
 		sb.WriteString("## Database Relationships\n\n")
 
 		mermaidGen := generator.NewMermaidGenerator()
@@ -65,18 +140,24 @@ func (r *MarkdownReporter) Generate(schema *models.Schema) (string, error) {
 
 	sb.WriteString("## Tables\n\n")
 
+	postgres := databaseEngine(schema) == "PostgreSQL"
+
+	if len(schema.Tables) == 0 {
+		sb.WriteString("No tables found in the database.\n")
+	}
+
 	for i := range schema.Tables {
 		if i > 0 {
 			sb.WriteString("\n---\n\n")
 		}
 
-		r.writeTable(&sb, &schema.Tables[i])
+		r.writeTable(&sb, &schema.Tables[i], anchors, postgres)
 	}
 
 	return sb.String(), nil
 }
 
-func (r *MarkdownReporter) writeTable(sb *strings.Builder, table *models.Table) {
+func (r *MarkdownReporter) writeTable(sb *strings.Builder, table *models.Table, anchors map[string]string, postgres bool) {
 	anchorName := strings.ToLower(strings.ReplaceAll(table.Name, "_", "-"))
 	fmt.Fprintf(sb, "## %s\n\n", table.Name)
 	fmt.Fprintf(sb, "<a id=\"%s\"></a>\n\n", anchorName)
@@ -85,50 +166,126 @@ func (r *MarkdownReporter) writeTable(sb *strings.Builder, table *models.Table)
 		fmt.Fprintf(sb, "Schema: `%s`\n\n", table.Schema)
 	}
 
-	if table.RowCount > 0 {
+	if table.Description != "" {
+		fmt.Fprintf(sb, "%s\n\n", table.Description)
+	}
+
+	if table.PartitionStrategy != "" {
+		fmt.Fprintf(sb, "Partitioned by: `%s (%s)`\n\n", table.PartitionStrategy, table.PartitionKey)
+	}
+
+	if table.PartitionOf != "" {
+		fmt.Fprintf(sb, "Partition of: `%s` `%s`\n\n", table.PartitionOf, table.PartitionBound)
+	}
+
+	switch {
+	case table.RowCountMethod != "":
+		fmt.Fprintf(sb, "Row Count: %d (%s, %s confidence)\n\n", table.RowCount, table.RowCountMethod, table.RowCountConfidence)
+	case table.RowCount > 0:
 		fmt.Fprintf(sb, "Row Count: %d\n\n", table.RowCount)
 	}
 
+	if table.TotalSizeBytes > 0 {
+		fmt.Fprintf(sb, "Size: %s (heap %s, TOAST %s, indexes %s)\n\n", formatBytes(table.TotalSizeBytes),
+			formatBytes(table.HeapSizeBytes), formatBytes(table.ToastSizeBytes), formatBytes(table.IndexesSizeBytes))
+	}
+
+	if len(table.Publications) > 0 {
+		fmt.Fprintf(sb, "Publications: `%s`\n\n", strings.Join(table.Publications, "`, `"))
+	}
+
 	sb.WriteString("### Columns\n\n")
-	sb.WriteString("| Column | Type | Nullable | Constraints | Default |\n")
-	sb.WriteString("|--------|------|----------|-------------|---------|\n")
+	sb.WriteString("| Column | Type | Nullable | Constraints | Default | Description |\n")
+	sb.WriteString("|--------|------|----------|-------------|---------|-------------|\n")
 
 	for _, col := range table.Columns {
-		r.writeColumn(sb, col)
+		r.writeColumn(sb, col, anchors)
+	}
+
+	if len(table.Constraints) > 0 {
+		sb.WriteString("\n### Constraints\n\n")
+		sb.WriteString("| Name | Type | Columns | Definition | Deferrable | Validated |\n")
+		sb.WriteString("|------|------|---------|------------|------------|-----------|\n")
+
+		for i := range table.Constraints {
+			r.writeConstraint(sb, &table.Constraints[i])
+		}
+	}
+
+	if len(table.ForeignKeys) > 0 {
+		sb.WriteString("\n### Foreign Keys\n\n")
+		sb.WriteString("| Name | Columns | References | On Delete | On Update |\n")
+		sb.WriteString("|------|---------|------------|-----------|-----------|\n")
+
+		for i := range table.ForeignKeys {
+			r.writeForeignKey(sb, &table.ForeignKeys[i])
+		}
 	}
 
 	if len(table.Indexes) > 0 {
 		sb.WriteString("\n### Indexes\n\n")
-		sb.WriteString("| Name | Type | Columns | Method |\n")
-		sb.WriteString("|------|------|---------|--------|\n")
+		sb.WriteString("| Name | Type | Columns | Method | Include | Predicate | Valid | Size |\n")
+		sb.WriteString("|------|------|---------|--------|---------|-----------|-------|------|\n")
 
 		for _, idx := range table.Indexes {
-			r.writeIndex(sb, &idx)
+			r.writeIndex(sb, &idx, postgres)
 		}
+
+		r.writeIndexDefinitions(sb, table.Indexes)
 	}
 
 	if len(table.Triggers) > 0 {
 		sb.WriteString("\n### Triggers\n\n")
-		sb.WriteString("| Name | Event | Timing | Function | Orientation |\n")
-		sb.WriteString("|------|-------|--------|----------|-------------|\n")
+		r.writeTriggers(sb, table.Triggers, anchors)
+	}
 
-		for _, trigger := range table.Triggers {
-			r.writeTrigger(sb, &trigger)
-		}
+	if table.Stats != nil {
+		r.writeTableActivity(sb, table.Stats)
+	}
+
+	if table.RowSecurity || len(table.Policies) > 0 {
+		r.writeRowSecurity(sb, table)
+	}
+
+	if len(table.Partitions) > 0 {
+		fmt.Fprintf(sb, "\n### Partitions (%d)\n\n", len(table.Partitions))
+		sb.WriteString("| Partition | Bound | Row Count |\n")
+		sb.WriteString("|-----------|-------|-----------|\n")
+
+		r.writePartitions(sb, table.Partitions, 0)
+	}
+}
+
+// writePartitions lists partitions with their bounds, indenting
+// sub-partitions beneath the partition they belong to.
+func (r *MarkdownReporter) writePartitions(sb *strings.Builder, partitions []models.Table, depth int) {
+	for i := range partitions {
+		partition := &partitions[i]
+
+		sb.WriteString("| ")
+		sb.WriteString(strings.Repeat("&nbsp;&nbsp;", depth))
+		fmt.Fprintf(sb, "%s.%s", partition.Schema, partition.Name)
+		sb.WriteString(" | `")
+		sb.WriteString(escapeTableCell(partition.PartitionBound))
+		sb.WriteString("` | ")
+		fmt.Fprintf(sb, "%d", partition.RowCount)
+		sb.WriteString(" |\n")
+
+		r.writePartitions(sb, partition.Partitions, depth+1)
 	}
 }
 
-func (r *MarkdownReporter) writeColumn(sb *strings.Builder, col models.Column) {
+func (r *MarkdownReporter) writeColumn(sb *strings.Builder, col models.Column, anchors map[string]string) {
 	sb.WriteString("| ")
 	sb.WriteString(col.Name)
 	sb.WriteString(" | ")
 
-	dataType := col.DataType
-	if col.MaxLength != nil {
-		dataType = fmt.Sprintf("%s(%d)", dataType, *col.MaxLength)
+	if anchor, exists := anchors[col.TypeRef]; exists {
+		fmt.Fprintf(sb, "[%s](#%s)", formatColumnType(&col), anchor)
+	} else {
+		sb.WriteString(formatColumnType(&col))
 	}
 
-	sb.WriteString(dataType)
 	sb.WriteString(" | ")
 
 	if col.IsNullable {
@@ -148,184 +305,1256 @@ func (r *MarkdownReporter) writeColumn(sb *strings.Builder, col models.Column) {
 		constraints = append(constraints, "UNIQUE")
 	}
 
+	switch {
+	case col.Identity == models.IdentityAutoIncrement:
+		constraints = append(constraints, col.Identity)
+	case col.Identity != "":
+		constraints = append(constraints, "IDENTITY "+col.Identity)
+	}
+
 	sb.WriteString(strings.Join(constraints, ", "))
 	sb.WriteString(" | ")
 
-	if col.DefaultValue != nil {
+	switch {
+	case col.Generated != "":
+		fmt.Fprintf(sb, "`GENERATED ALWAYS AS (%s) %s`", escapeTableCell(col.GenerationExpression), col.Generated)
+	case col.DefaultValue != nil:
 		sb.WriteString(*col.DefaultValue)
 	}
 
+	sb.WriteString(" | ")
+	sb.WriteString(escapeTableCell(col.Description))
 	sb.WriteString(" |\n")
 }
 
-func (r *MarkdownReporter) writeIndex(sb *strings.Builder, idx *models.Index) {
+func (r *MarkdownReporter) writeConstraint(sb *strings.Builder, constraint *models.Constraint) {
 	sb.WriteString("| ")
-	sb.WriteString(idx.Name)
+	sb.WriteString(constraint.Name)
 	sb.WriteString(" | ")
-	sb.WriteString(idx.Type)
+	sb.WriteString(constraint.Type)
 	sb.WriteString(" | ")
-	sb.WriteString(strings.Join(idx.Columns, ", "))
+	sb.WriteString(strings.Join(constraint.Columns, ", "))
+	sb.WriteString(" | `")
+	sb.WriteString(escapeTableCell(constraint.Definition))
+	sb.WriteString("` | ")
+
+	switch {
+	case constraint.IsDeferred:
+		sb.WriteString("DEFERRABLE INITIALLY DEFERRED")
+	case constraint.IsDeferrable:
+		sb.WriteString("DEFERRABLE INITIALLY IMMEDIATE")
+	default:
+		sb.WriteString("NO")
+	}
+
 	sb.WriteString(" | ")
-	sb.WriteString(idx.Method)
+
+	if constraint.IsValidated {
+		sb.WriteString("YES")
+	} else {
+		sb.WriteString("NO (NOT VALID)")
+	}
+
 	sb.WriteString(" |\n")
 }
 
-func (r *MarkdownReporter) writeTrigger(sb *strings.Builder, trigger *models.Trigger) {
+func (r *MarkdownReporter) writeForeignKey(sb *strings.Builder, fk *models.ForeignKey) {
 	sb.WriteString("| ")
-	sb.WriteString(trigger.Name)
+	sb.WriteString(fk.Name)
 	sb.WriteString(" | ")
-	sb.WriteString(trigger.Event)
+	sb.WriteString(strings.Join(fk.SourceColumns, ", "))
 	sb.WriteString(" | ")
-	sb.WriteString(trigger.Timing)
+	fmt.Fprintf(sb, "%s(%s)", fk.ReferencedTable, strings.Join(fk.ReferencedColumns, ", "))
 	sb.WriteString(" | ")
-	sb.WriteString(trigger.Function)
+	sb.WriteString(fk.OnDelete)
 	sb.WriteString(" | ")
-	sb.WriteString(trigger.Orientation)
+	sb.WriteString(fk.OnUpdate)
 	sb.WriteString(" |\n")
 }
 
-func (r *MarkdownReporter) hasRelationships(tables []models.Table) bool {
-	for i := range tables {
-		if len(tables[i].ForeignKeys) > 0 {
-			return true
+// formatColumnType renders a column type with its modifiers the way
+// PostgreSQL's format_type does, e.g. numeric(12,2) or
+// timestamp(3) with time zone, followed by any non-default collation.
+func formatColumnType(col *models.Column) string {
+	dataType := col.DataType
+
+	switch {
+	case col.MaxLength != nil:
+		dataType = fmt.Sprintf("%s(%d)", dataType, *col.MaxLength)
+	case col.Precision != nil && col.Scale != nil:
+		dataType = fmt.Sprintf("%s(%d,%d)", dataType, *col.Precision, *col.Scale)
+	case col.Precision != nil:
+		// Time types take the precision after their first word.
+		name, rest, found := strings.Cut(dataType, " ")
+
+		dataType = fmt.Sprintf("%s(%d)", name, *col.Precision)
+		if found {
+			dataType += " " + rest
 		}
 	}
 
-	return false
-}
+	if col.ArrayDimensions > 1 && strings.HasSuffix(dataType, "[]") {
+		dataType += strings.Repeat("[]", col.ArrayDimensions-1)
+	}
 
-func (r *MarkdownReporter) writeTableOfContents(sb *strings.Builder, tables []models.Table, views []models.View, sequences []models.Sequence, extensions []models.Extension) {
-	sb.WriteString("## Table of Contents\n\n")
+	if col.Collation != "" {
+		dataType += ` COLLATE "` + col.Collation + `"`
+	}
 
-	sb.WriteString("- [Database Summary](#database-summary)\n")
+	return dataType
+}
 
-	if len(extensions) > 0 {
-		sb.WriteString("- [PostgreSQL Extensions](#postgresql-extensions)\n")
-	}
+func (r *MarkdownReporter) writeIndex(sb *strings.Builder, idx *models.Index, postgres bool) {
+	sb.WriteString("| ")
+	sb.WriteString(idx.Name)
+	sb.WriteString(" | ")
+	sb.WriteString(idx.Type)
+	sb.WriteString(" | ")
+	sb.WriteString(escapeTableCell(r.formatIndexKey(idx, postgres)))
+	sb.WriteString(" | ")
+	sb.WriteString(idx.Method)
+	sb.WriteString(" | ")
+	sb.WriteString(escapeTableCell(strings.Join(idx.Include, ", ")))
+	sb.WriteString(" | ")
+	sb.WriteString(formatExpression(idx.Predicate))
+	sb.WriteString(" | ")
 
-	if len(views) > 0 {
-		sb.WriteString("- [Views](#views)\n")
+	if idx.IsValid {
+		sb.WriteString("YES")
+	} else {
+		sb.WriteString("NO")
 	}
 
-	if len(sequences) > 0 {
-		sb.WriteString("- [Sequences](#sequences)\n")
+	sb.WriteString(" | ")
+
+	// MariaDB and MySQL only report sizes of InnoDB indexes, and only with
+	// access to mysql.innodb_index_stats, so leave the cell blank otherwise.
+	if idx.SizeBytes > 0 {
+		sb.WriteString(formatBytes(idx.SizeBytes))
 	}
 
-	hasRelationships := r.hasRelationships(tables)
-	if hasRelationships {
-		sb.WriteString("- [Database Relationships](#database-relationships)\n")
+	sb.WriteString(" |\n")
+}
+
+// formatIndexKey lists the key parts in index order, spelling out operator
+// classes and sort orders only where they differ from the defaults. Indexes
+// without key part details fall back to their column names. NULLS FIRST and
+// NULLS LAST are PostgreSQL syntax, so other databases only get DESC.
+func (r *MarkdownReporter) formatIndexKey(idx *models.Index, postgres bool) string {
+	if len(idx.KeyParts) == 0 {
+		return strings.Join(idx.Columns, ", ")
 	}
 
-	sb.WriteString("- [Tables](#tables)\n")
+	parts := make([]string, len(idx.KeyParts))
 
-	for i := range tables {
-		anchorName := strings.ToLower(strings.ReplaceAll(tables[i].Name, "_", "-"))
-		fmt.Fprintf(sb, "  - [%s](#%s)\n", tables[i].Name, anchorName)
+	for i, part := range idx.KeyParts {
+		text := part.Column
+		if part.Expression != "" {
+			text = part.Expression
+		}
+
+		if part.OpClass != "" {
+			text += " " + part.OpClass
+		}
+
+		switch {
+		case part.Descending && !postgres:
+			text += " DESC"
+		case part.Descending && !part.NullsFirst:
+			text += " DESC NULLS LAST"
+		case part.Descending:
+			text += " DESC"
+		case part.NullsFirst:
+			text += " NULLS FIRST"
+		}
+
+		parts[i] = text
 	}
 
-	sb.WriteString("\n")
+	return strings.Join(parts, ", ")
 }
 
-func (r *MarkdownReporter) writeExtensions(sb *strings.Builder, extensions []models.Extension) {
-	sb.WriteString("## PostgreSQL Extensions\n\n")
+// writeIndexDefinitions lists the CREATE INDEX statements below an index
+// table, when the database reports them.
+func (r *MarkdownReporter) writeIndexDefinitions(sb *strings.Builder, indexes []models.Index) {
+	var definitions []string
 
-	if len(extensions) == 0 {
-		sb.WriteString("No extensions are installed.\n\n")
+	for i := range indexes {
+		if indexes[i].Definition != "" {
+			definitions = append(definitions, indexes[i].Definition+";")
+		}
+	}
+
+	if len(definitions) == 0 {
 		return
 	}
 
-	sb.WriteString("| Extension | Version | Schema |\n")
-	sb.WriteString("|-----------|---------|--------|\n")
+	sb.WriteString("\n```sql\n")
+	sb.WriteString(strings.Join(definitions, "\n"))
+	sb.WriteString("\n```\n")
+}
 
-	for i := range extensions {
-		r.writeExtension(sb, &extensions[i])
+// writeTriggers lists triggers followed by their full definitions.
+func (r *MarkdownReporter) writeTriggers(sb *strings.Builder, triggers []models.Trigger, anchors map[string]string) {
+	sb.WriteString("| Name | Event | Timing | Function | Orientation | Enabled | Condition | Transition Tables | Order |\n")
+	sb.WriteString("|------|-------|--------|----------|-------------|---------|-----------|-------------------|-------|\n")
+
+	var definitions []string
+
+	for i := range triggers {
+		r.writeTrigger(sb, &triggers[i], anchors)
+
+		if triggers[i].Definition != "" {
+			definitions = append(definitions, triggers[i].Definition+";")
+		}
 	}
 
-	sb.WriteString("\n")
+	if len(definitions) > 0 {
+		sb.WriteString("\n```sql\n")
+		sb.WriteString(strings.Join(definitions, "\n"))
+		sb.WriteString("\n```\n")
+	}
 }
 
-func (r *MarkdownReporter) writeExtension(sb *strings.Builder, ext *models.Extension) {
+func (r *MarkdownReporter) writeTrigger(sb *strings.Builder, trigger *models.Trigger, anchors map[string]string) {
 	sb.WriteString("| ")
-	sb.WriteString(ext.Name)
+	sb.WriteString(trigger.Name)
+
+	if trigger.IsConstraint {
+		sb.WriteString(" (constraint)")
+	}
+
 	sb.WriteString(" | ")
-	sb.WriteString(ext.Version)
+	sb.WriteString(formatTriggerEvent(trigger))
 	sb.WriteString(" | ")
-	sb.WriteString(ext.Schema)
+	sb.WriteString(trigger.Timing)
+	sb.WriteString(" | ")
+
+	// Trigger functions take no arguments
+	if anchor, exists := anchors[routineKey(trigger.FunctionSchema, trigger.Function, "")]; exists {
+		fmt.Fprintf(sb, "[%s](#%s)", trigger.Function, anchor)
+	} else {
+		sb.WriteString(trigger.Function)
+	}
+
+	sb.WriteString(" | ")
+	sb.WriteString(trigger.Orientation)
+	sb.WriteString(" | ")
+	sb.WriteString(trigger.Enabled)
+	sb.WriteString(" | ")
+	sb.WriteString(formatExpression(trigger.Condition))
+	sb.WriteString(" | ")
+
+	var transitions []string
+	if trigger.OldTable != "" {
+		transitions = append(transitions, "OLD TABLE AS "+trigger.OldTable)
+	}
+
+	if trigger.NewTable != "" {
+		transitions = append(transitions, "NEW TABLE AS "+trigger.NewTable)
+	}
+
+	sb.WriteString(strings.Join(transitions, ", "))
+	sb.WriteString(" | ")
+
+	if trigger.ActionOrder > 0 {
+		fmt.Fprintf(sb, "%d", trigger.ActionOrder)
+	}
+
 	sb.WriteString(" |\n")
 }
 
-func (r *MarkdownReporter) writeViews(sb *strings.Builder, views []models.View) {
-	sb.WriteString("## Views\n\n")
+// formatTriggerEvent spells out UPDATE OF when an update trigger is limited
+// to specific columns.
+func formatTriggerEvent(trigger *models.Trigger) string {
+	if len(trigger.Columns) == 0 {
+		return trigger.Event
+	}
 
-	if len(views) == 0 {
-		sb.WriteString("No views are defined.\n\n")
-		return
+	events := strings.Split(trigger.Event, ",")
+	for i, event := range events {
+		if event == "UPDATE" {
+			events[i] = "UPDATE OF " + strings.Join(trigger.Columns, ", ")
+		}
 	}
 
-	sb.WriteString("| View | Schema |\n")
-	sb.WriteString("|------|--------|\n")
+	return strings.Join(events, ",")
+}
 
-	for i := range views {
-		r.writeView(sb, &views[i])
+func hasDatabaseObjects(schema *models.Schema) bool {
+	return len(schema.EventTriggers) > 0 || len(schema.Rules) > 0 || len(schema.Publications) > 0
+}
+
+// writeDatabaseObjects documents objects that change data or react to DDL
+// without appearing in any table's own definition.
+func (r *MarkdownReporter) writeDatabaseObjects(sb *strings.Builder, schema *models.Schema, anchors map[string]string) {
+	sb.WriteString("## Database-level Objects\n\n")
+
+	if len(schema.EventTriggers) > 0 {
+		sb.WriteString("### Event Triggers\n\n")
+		sb.WriteString("| Name | Event | Tags | Function | Enabled | Owner | Description |\n")
+		sb.WriteString("|------|-------|------|----------|---------|-------|-------------|\n")
+
+		for i := range schema.EventTriggers {
+			r.writeEventTrigger(sb, &schema.EventTriggers[i], anchors)
+		}
+
+		sb.WriteString("\n")
 	}
 
-	sb.WriteString("\n")
+	if len(schema.Rules) > 0 {
+		r.writeRules(sb, schema.Rules)
+	}
+
+	if len(schema.Publications) > 0 {
+		r.writePublications(sb, schema.Publications)
+	}
 }
 
-func (r *MarkdownReporter) writeView(sb *strings.Builder, view *models.View) {
-	sb.WriteString("| ")
-	sb.WriteString(view.Name)
-	sb.WriteString(" | ")
-	sb.WriteString(view.Schema)
-	sb.WriteString(" |\n")
+func (r *MarkdownReporter) writeEventTrigger(sb *strings.Builder, trigger *models.EventTrigger, anchors map[string]string) {
+	tags := "all commands"
+	if len(trigger.Tags) > 0 {
+		tags = strings.Join(trigger.Tags, ", ")
+	}
+
+	function := trigger.Function
+	if anchor, exists := anchors[routineKey(trigger.FunctionSchema, trigger.Function, "")]; exists {
+		function = fmt.Sprintf("[%s](#%s)", trigger.Function, anchor)
+	}
+
+	fmt.Fprintf(sb, "| %s | %s | %s | %s | %s | %s | %s |\n", trigger.Name, trigger.Event, tags, function,
+		trigger.Enabled, trigger.Owner, escapeTableCell(trigger.Description))
 }
 
-func (r *MarkdownReporter) writeSequences(sb *strings.Builder, sequences []models.Sequence) {
-	sb.WriteString("## Sequences\n\n")
+func (r *MarkdownReporter) writeRules(sb *strings.Builder, rules []models.Rule) {
+	sb.WriteString("### Rules\n\n")
+	sb.WriteString("| Rule | Table | Event | Type | Enabled |\n")
+	sb.WriteString("|------|-------|-------|------|---------|\n")
 
-	if len(sequences) == 0 {
-		sb.WriteString("No sequences are defined.\n\n")
-		return
+	definitions := make([]string, len(rules))
+
+	for i := range rules {
+		rule := &rules[i]
+
+		ruleType := "ALSO"
+		if rule.IsInstead {
+			ruleType = "INSTEAD"
+		}
+
+		fmt.Fprintf(sb, "| %s | %s.%s | %s | %s | %s |\n", rule.Name, rule.Schema, rule.Table, rule.Event, ruleType, rule.Enabled)
+
+		definitions[i] = rule.Definition
 	}
 
-	sb.WriteString("| Sequence | Schema | Data Type | Start | Min | Max | Increment |\n")
-	sb.WriteString("|----------|--------|-----------|-------|-----|-----|----------|\n")
+	sb.WriteString("\n```sql\n")
+	sb.WriteString(strings.Join(definitions, "\n"))
+	sb.WriteString("\n```\n\n")
+}
 
-	for i := range sequences {
-		r.writeSequence(sb, &sequences[i])
+func (r *MarkdownReporter) writePublications(sb *strings.Builder, publications []models.Publication) {
+	sb.WriteString("### Publications\n\n")
+	sb.WriteString("| Publication | Owner | Operations | Tables |\n")
+	sb.WriteString("|-------------|-------|------------|--------|\n")
+
+	for i := range publications {
+		publication := &publications[i]
+
+		tables := strings.Join(publication.Tables, ", ")
+		if publication.AllTables {
+			tables = "all tables"
+		}
+
+		fmt.Fprintf(sb, "| %s | %s | %s | %s |\n", publication.Name, publication.Owner,
+			strings.Join(publication.Operations, ", "), tables)
 	}
 
 	sb.WriteString("\n")
 }
 
-func (r *MarkdownReporter) writeSequence(sb *strings.Builder, seq *models.Sequence) {
+// writeTableActivity lists scan and write counters, dead tuples, the last
+// vacuum and analyze runs and buffer cache hit ratios.
+func (r *MarkdownReporter) writeTableActivity(sb *strings.Builder, stats *models.TableStats) {
+	sb.WriteString("\n### Activity\n\n")
+	sb.WriteString("| Metric | Value |\n")
+	sb.WriteString("|--------|-------|\n")
+
+	fmt.Fprintf(sb, "| Sequential scans | %d |\n", stats.SeqScans)
+	fmt.Fprintf(sb, "| Index scans | %d |\n", stats.IndexScans)
+	fmt.Fprintf(sb, "| Rows inserted | %d |\n", stats.TuplesInserted)
+	fmt.Fprintf(sb, "| Rows updated | %d |\n", stats.TuplesUpdated)
+	fmt.Fprintf(sb, "| Rows deleted | %d |\n", stats.TuplesDeleted)
+	fmt.Fprintf(sb, "| Live tuples | %d |\n", stats.LiveTuples)
+	fmt.Fprintf(sb, "| Dead tuples | %d |\n", stats.DeadTuples)
+	fmt.Fprintf(sb, "| Last vacuum | %s |\n", formatStatsTime(stats.LastVacuum))
+	fmt.Fprintf(sb, "| Last autovacuum | %s |\n", formatStatsTime(stats.LastAutovacuum))
+	fmt.Fprintf(sb, "| Last analyze | %s |\n", formatStatsTime(stats.LastAnalyze))
+	fmt.Fprintf(sb, "| Last autoanalyze | %s |\n", formatStatsTime(stats.LastAutoanalyze))
+	fmt.Fprintf(sb, "| Heap cache hit ratio | %s |\n", formatHitRatio(stats.HeapBlocksHit, stats.HeapBlocksRead))
+	fmt.Fprintf(sb, "| Index cache hit ratio | %s |\n", formatHitRatio(stats.IndexBlocksHit, stats.IndexBlocksRead))
+}
+
+// writeRowSecurity documents whether row-level security is enforced and the
+// policies that decide which rows each role can see or write. Policies are
+// listed even when RLS is disabled, because they take effect once it is enabled.
+func (r *MarkdownReporter) writeRowSecurity(sb *strings.Builder, table *models.Table) {
+	sb.WriteString("\n### Row-Level Security\n\n")
+
+	switch {
+	case table.ForceRowSecurity:
+		sb.WriteString("Row-level security: **enabled** (forced for the table owner)\n\n")
+	case table.RowSecurity:
+		sb.WriteString("Row-level security: **enabled**\n\n")
+	default:
+		sb.WriteString("Row-level security: **disabled**\n\n")
+	}
+
+	if len(table.Policies) == 0 {
+		sb.WriteString("No policies are defined, so no rows are visible to roles subject to row-level security.\n")
+		return
+	}
+
+	sb.WriteString("| Policy | Command | Type | Roles | USING | WITH CHECK |\n")
+	sb.WriteString("|--------|---------|------|-------|-------|------------|\n")
+
+	for i := range table.Policies {
+		r.writePolicy(sb, &table.Policies[i])
+	}
+}
+
+func (r *MarkdownReporter) writePolicy(sb *strings.Builder, policy *models.Policy) {
 	sb.WriteString("| ")
-	sb.WriteString(seq.Name)
-	sb.WriteString(" | ")
-	sb.WriteString(seq.Schema)
+	sb.WriteString(policy.Name)
 	sb.WriteString(" | ")
-	sb.WriteString(seq.DataType)
+	sb.WriteString(policy.Command)
 	sb.WriteString(" | ")
-	fmt.Fprintf(sb, "%d", seq.StartValue)
+
+	if policy.IsPermissive {
+		sb.WriteString("PERMISSIVE")
+	} else {
+		sb.WriteString("RESTRICTIVE")
+	}
+
 	sb.WriteString(" | ")
-	fmt.Fprintf(sb, "%d", seq.MinValue)
+	sb.WriteString(strings.Join(policy.Roles, ", "))
 	sb.WriteString(" | ")
-	fmt.Fprintf(sb, "%d", seq.MaxValue)
+	sb.WriteString(formatExpression(policy.Using))
 	sb.WriteString(" | ")
-	fmt.Fprintf(sb, "%d", seq.Increment)
+	sb.WriteString(formatExpression(policy.WithCheck))
 	sb.WriteString(" |\n")
 }
 
-func (r *MarkdownReporter) writeDatabaseSummary(sb *strings.Builder, tables []models.Table) {
-	sb.WriteString("## Database Summary\n\n")
-
-	tableCount := len(tables)
+// hasERDiagram reports whether the ER diagram has anything to show beyond
+// unconnected local tables: relationships, or foreign tables to set apart.
+func (r *MarkdownReporter) hasERDiagram(schema *models.Schema) bool {
+	return r.hasRelationships(schema.Tables) || len(schema.ForeignTables) > 0
+}
 
-	var totalRows int64
+func (r *MarkdownReporter) hasRelationships(tables []models.Table) bool {
+	for i := range tables {
+		if len(tables[i].ForeignKeys) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *MarkdownReporter) writeTableOfContents(sb *strings.Builder, schema *models.Schema) {
+	tables := schema.Tables
+
+	sb.WriteString("## Table of Contents\n\n")
+
+	sb.WriteString("- [Database Summary](#database-summary)\n")
+
+	if len(schema.Extensions) > 0 {
+		sb.WriteString("- [PostgreSQL Extensions](#postgresql-extensions)\n")
+	}
+
+	if len(schema.Types) > 0 {
+		sb.WriteString("- [Types](#types)\n")
+	}
+
+	if len(schema.Views) > 0 {
+		sb.WriteString("- [Views](#views)\n")
+	}
+
+	if len(schema.MaterializedViews) > 0 {
+		sb.WriteString("- [Materialized Views](#materialized-views)\n")
+	}
+
+	if hasForeignData(schema) {
+		sb.WriteString("- [Foreign Data](#foreign-data)\n")
+	}
+
+	if r.hasViewDependencies(schema) {
+		sb.WriteString("- [View Dependencies](#view-dependencies)\n")
+	}
+
+	if len(schema.Sequences) > 0 {
+		sb.WriteString("- [Sequences](#sequences)\n")
+	}
+
+	if len(schema.Routines) > 0 {
+		sb.WriteString("- [Functions](#functions)\n")
+	}
+
+	if len(schema.Grants) > 0 {
+		sb.WriteString("- [Privileges](#privileges)\n")
+	}
+
+	if hasDatabaseObjects(schema) {
+		sb.WriteString("- [Database-level Objects](#database-level-objects)\n")
+	}
+
+	if r.hasERDiagram(schema) {
+		sb.WriteString("- [Database Relationships](#database-relationships)\n")
+	}
+
+	sb.WriteString("- [Tables](#tables)\n")
+
+	for i := range tables {
+		anchorName := strings.ToLower(strings.ReplaceAll(tables[i].Name, "_", "-"))
+		fmt.Fprintf(sb, "  - [%s](#%s)\n", tables[i].Name, anchorName)
+	}
+
+	sb.WriteString("\n")
+}
+
+func (r *MarkdownReporter) writeExtensions(sb *strings.Builder, extensions []models.Extension) {
+	sb.WriteString("## PostgreSQL Extensions\n\n")
+
+	if len(extensions) == 0 {
+		sb.WriteString("No extensions are installed.\n\n")
+		return
+	}
+
+	sb.WriteString("| Extension | Version | Schema | Upgrade Available |\n")
+	sb.WriteString("|-----------|---------|--------|-------------------|\n")
+
+	for i := range extensions {
+		r.writeExtension(sb, &extensions[i])
+	}
+
+	sb.WriteString("\n")
+}
+
+func (r *MarkdownReporter) writeExtension(sb *strings.Builder, ext *models.Extension) {
+	sb.WriteString("| ")
+	sb.WriteString(ext.Name)
+	sb.WriteString(" | ")
+	sb.WriteString(ext.Version)
+	sb.WriteString(" | ")
+	sb.WriteString(ext.Schema)
+	sb.WriteString(" | ")
+
+	if ext.HasUpgrade() {
+		sb.WriteString(ext.DefaultVersion)
+	}
+
+	sb.WriteString(" |\n")
+}
+
+func (r *MarkdownReporter) writeTypes(sb *strings.Builder, types []models.Type) {
+	sb.WriteString("## Types\n\n")
+
+	sb.WriteString("| Type | Schema | Kind | Description |\n")
+	sb.WriteString("|------|--------|------|-------------|\n")
+
+	for i := range types {
+		typ := &types[i]
+		fmt.Fprintf(sb, "| [%s](#%s) | %s | %s | %s |\n",
+			typ.Name, typeAnchor(typ.Schema, typ.Name), typ.Schema, typ.Kind, escapeTableCell(typ.Description))
+	}
+
+	sb.WriteString("\n")
+
+	for i := range types {
+		r.writeType(sb, &types[i])
+	}
+}
+
+func (r *MarkdownReporter) writeType(sb *strings.Builder, typ *models.Type) {
+	fmt.Fprintf(sb, "### %s.%s\n\n", typ.Schema, typ.Name)
+	fmt.Fprintf(sb, "<a id=\"%s\"></a>\n\n", typeAnchor(typ.Schema, typ.Name))
+
+	if typ.Description != "" {
+		fmt.Fprintf(sb, "%s\n\n", typ.Description)
+	}
+
+	switch typ.Kind {
+	case "enum":
+		labels := make([]string, len(typ.Labels))
+		for i, label := range typ.Labels {
+			labels[i] = "`" + label + "`"
+		}
+
+		fmt.Fprintf(sb, "**Values:** %s\n\n", strings.Join(labels, ", "))
+	case "domain":
+		fmt.Fprintf(sb, "**Base type:** `%s`", typ.BaseType)
+
+		if typ.NotNull {
+			sb.WriteString(" NOT NULL")
+		}
+
+		sb.WriteString("\n\n")
+
+		if typ.DefaultValue != nil {
+			fmt.Fprintf(sb, "**Default:** `%s`\n\n", *typ.DefaultValue)
+		}
+
+		for _, constraint := range typ.Constraints {
+			fmt.Fprintf(sb, "- `%s`\n", constraint)
+		}
+
+		if len(typ.Constraints) > 0 {
+			sb.WriteString("\n")
+		}
+	case "composite":
+		sb.WriteString("| Attribute | Type |\n")
+		sb.WriteString("|-----------|------|\n")
+
+		for _, attr := range typ.Attributes {
+			fmt.Fprintf(sb, "| %s | %s |\n", attr.Name, attr.DataType)
+		}
+
+		sb.WriteString("\n")
+	case "range":
+		fmt.Fprintf(sb, "**Subtype:** `%s`\n\n", typ.Subtype)
+	}
+}
+
+// objectAnchors maps documented types and routines to their anchors so
+// columns can link to their type and triggers to the function they call.
+// Types are keyed by qualified name, routines by routineKey.
+func (r *MarkdownReporter) objectAnchors(schema *models.Schema) map[string]string {
+	anchors := make(map[string]string, len(schema.Types)+len(schema.Routines))
+	for i := range schema.Types {
+		anchors[schema.Types[i].Schema+"."+schema.Types[i].Name] = typeAnchor(schema.Types[i].Schema, schema.Types[i].Name)
+	}
+
+	for i := range schema.Routines {
+		routine := &schema.Routines[i]
+		anchors[routineKey(routine.Schema, routine.Name, routine.Arguments)] = routineAnchor(routine)
+	}
+
+	return anchors
+}
+
+func (r *MarkdownReporter) writeViews(sb *strings.Builder, views []models.View, anchors map[string]string) {
+	sb.WriteString("## Views\n\n")
+
+	if len(views) == 0 {
+		sb.WriteString("No views are defined.\n\n")
+		return
+	}
+
+	sb.WriteString("| View | Schema | Description |\n")
+	sb.WriteString("|------|--------|-------------|\n")
+
+	for i := range views {
+		r.writeView(sb, &views[i])
+	}
+
+	sb.WriteString("\n")
+
+	for i := range views {
+		r.writeViewDetails(sb, &views[i], anchors)
+	}
+}
+
+func (r *MarkdownReporter) writeView(sb *strings.Builder, view *models.View) {
+	sb.WriteString("| ")
+	sb.WriteString(view.Name)
+	sb.WriteString(" | ")
+	sb.WriteString(view.Schema)
+	sb.WriteString(" | ")
+	sb.WriteString(escapeTableCell(view.Description))
+	sb.WriteString(" |\n")
+}
+
+func (r *MarkdownReporter) writeViewDetails(sb *strings.Builder, view *models.View, anchors map[string]string) {
+	if view.Definition == "" && len(view.Columns) == 0 && len(view.Dependencies) == 0 && len(view.Triggers) == 0 {
+		return
+	}
+
+	fmt.Fprintf(sb, "### %s.%s\n\n", view.Schema, view.Name)
+
+	if view.Description != "" {
+		fmt.Fprintf(sb, "%s\n\n", view.Description)
+	}
+
+	r.writeViewColumns(sb, view.Columns)
+	r.writeDependencies(sb, view.Dependencies)
+
+	if len(view.Triggers) > 0 {
+		sb.WriteString("**Triggers:**\n\n")
+		r.writeTriggers(sb, view.Triggers, anchors)
+		sb.WriteString("\n")
+	}
+
+	r.writeDefinition(sb, view.Definition)
+}
+
+func (r *MarkdownReporter) writeViewColumns(sb *strings.Builder, columns []models.Column) {
+	if len(columns) == 0 {
+		return
+	}
+
+	sb.WriteString("| Column | Type | Nullable | Description |\n")
+	sb.WriteString("|--------|------|----------|-------------|\n")
+
+	for _, col := range columns {
+		sb.WriteString("| ")
+		sb.WriteString(col.Name)
+		sb.WriteString(" | ")
+		sb.WriteString(formatColumnType(&col))
+		sb.WriteString(" | ")
+
+		if col.IsNullable {
+			sb.WriteString("YES")
+		} else {
+			sb.WriteString("NO")
+		}
+
+		sb.WriteString(" | ")
+		sb.WriteString(escapeTableCell(col.Description))
+		sb.WriteString(" |\n")
+	}
+
+	sb.WriteString("\n")
+}
+
+func (r *MarkdownReporter) writeDependencies(sb *strings.Builder, dependencies []models.Dependency) {
+	if len(dependencies) == 0 {
+		return
+	}
+
+	names := make([]string, len(dependencies))
+	for i, dep := range dependencies {
+		names[i] = fmt.Sprintf("`%s.%s` (%s)", dep.Schema, dep.Name, dep.Kind)
+	}
+
+	fmt.Fprintf(sb, "**Depends on:** %s\n\n", strings.Join(names, ", "))
+}
+
+func (r *MarkdownReporter) writeDefinition(sb *strings.Builder, definition string) {
+	if definition == "" {
+		return
+	}
+
+	sb.WriteString("```sql\n")
+	sb.WriteString(strings.TrimSpace(definition))
+	sb.WriteString("\n```\n\n")
+}
+
+func (r *MarkdownReporter) hasViewDependencies(schema *models.Schema) bool {
+	for i := range schema.Views {
+		if len(schema.Views[i].Dependencies) > 0 {
+			return true
+		}
+	}
+
+	for i := range schema.MaterializedViews {
+		if len(schema.MaterializedViews[i].Dependencies) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *MarkdownReporter) writeMaterializedViews(sb *strings.Builder, views []models.MaterializedView) {
+	sb.WriteString("## Materialized Views\n\n")
+
+	sb.WriteString("| Materialized View | Schema | Populated | Size | Description |\n")
+	sb.WriteString("|-------------------|--------|-----------|------|-------------|\n")
+
+	for i := range views {
+		view := &views[i]
+
+		sb.WriteString("| ")
+		sb.WriteString(view.Name)
+		sb.WriteString(" | ")
+		sb.WriteString(view.Schema)
+		sb.WriteString(" | ")
+
+		if view.IsPopulated {
+			sb.WriteString("YES")
+		} else {
+			sb.WriteString("NO")
+		}
+
+		sb.WriteString(" | ")
+		sb.WriteString(formatBytes(view.SizeBytes))
+		sb.WriteString(" | ")
+		sb.WriteString(escapeTableCell(view.Description))
+		sb.WriteString(" |\n")
+	}
+
+	sb.WriteString("\n")
+
+	for i := range views {
+		r.writeMaterializedViewDetails(sb, &views[i])
+	}
+}
+
+func (r *MarkdownReporter) writeMaterializedViewDetails(sb *strings.Builder, view *models.MaterializedView) {
+	fmt.Fprintf(sb, "### %s.%s\n\n", view.Schema, view.Name)
+
+	if view.Description != "" {
+		fmt.Fprintf(sb, "%s\n\n", view.Description)
+	}
+
+	if !view.IsPopulated {
+		sb.WriteString("> Not populated: run `REFRESH MATERIALIZED VIEW` before querying.\n\n")
+	}
+
+	r.writeViewColumns(sb, view.Columns)
+
+	if len(view.Indexes) > 0 {
+		sb.WriteString("| Index | Type | Columns | Method | Include | Predicate | Valid | Size |\n")
+		sb.WriteString("|-------|------|---------|--------|---------|-----------|-------|------|\n")
+
+		for i := range view.Indexes {
+			r.writeIndex(sb, &view.Indexes[i], true)
+		}
+
+		r.writeIndexDefinitions(sb, view.Indexes)
+
+		sb.WriteString("\n")
+	}
+
+	r.writeDependencies(sb, view.Dependencies)
+	r.writeDefinition(sb, view.Definition)
+}
+
+func hasForeignData(schema *models.Schema) bool {
+	return len(schema.ForeignDataWrappers) > 0 || len(schema.ForeignServers) > 0 || len(schema.ForeignTables) > 0
+}
+
+// writeForeignData documents where federated data comes from: the wrappers,
+// the servers and user mappings built on them, and the foreign tables that
+// read through those servers.
+func (r *MarkdownReporter) writeForeignData(sb *strings.Builder, schema *models.Schema) {
+	sb.WriteString("## Foreign Data\n\n")
+
+	if len(schema.ForeignDataWrappers) > 0 {
+		sb.WriteString("### Foreign Data Wrappers\n\n")
+		sb.WriteString("| Wrapper | Handler | Validator | Owner | Options |\n")
+		sb.WriteString("|---------|---------|-----------|-------|---------|\n")
+
+		for i := range schema.ForeignDataWrappers {
+			wrapper := &schema.ForeignDataWrappers[i]
+			fmt.Fprintf(sb, "| %s | %s | %s | %s | %s |\n", wrapper.Name, wrapper.Handler, wrapper.Validator,
+				wrapper.Owner, formatOptions(wrapper.Options))
+		}
+
+		sb.WriteString("\n")
+	}
+
+	if len(schema.ForeignServers) > 0 {
+		r.writeForeignServers(sb, schema.ForeignServers)
+	}
+
+	if len(schema.ForeignTables) > 0 {
+		r.writeForeignTables(sb, schema.ForeignTables)
+	}
+}
+
+func (r *MarkdownReporter) writeForeignServers(sb *strings.Builder, servers []models.ForeignServer) {
+	sb.WriteString("### Foreign Servers\n\n")
+	sb.WriteString("| Server | Wrapper | Type | Version | Owner | Options |\n")
+	sb.WriteString("|--------|---------|------|---------|-------|---------|\n")
+
+	hasMappings := false
+
+	for i := range servers {
+		server := &servers[i]
+		fmt.Fprintf(sb, "| %s | %s | %s | %s | %s | %s |\n", server.Name, server.Wrapper, server.Type, server.Version,
+			server.Owner, formatOptions(server.Options))
+
+		hasMappings = hasMappings || len(server.UserMappings) > 0
+	}
+
+	sb.WriteString("\n")
+
+	if !hasMappings {
+		return
+	}
+
+	sb.WriteString("**User Mappings:**\n\n")
+	sb.WriteString("| Server | User | Options |\n")
+	sb.WriteString("|--------|------|---------|\n")
+
+	for i := range servers {
+		for _, mapping := range servers[i].UserMappings {
+			fmt.Fprintf(sb, "| %s | %s | %s |\n", servers[i].Name, mapping.User, formatOptions(mapping.Options))
+		}
+	}
+
+	sb.WriteString("\n")
+}
+
+func (r *MarkdownReporter) writeForeignTables(sb *strings.Builder, tables []models.ForeignTable) {
+	sb.WriteString("### Foreign Tables\n\n")
+	sb.WriteString("| Foreign Table | Schema | Server | Options | Description |\n")
+	sb.WriteString("|---------------|--------|--------|---------|-------------|\n")
+
+	for i := range tables {
+		table := &tables[i]
+		fmt.Fprintf(sb, "| %s | %s | %s | %s | %s |\n", table.Name, table.Schema, table.Server,
+			formatOptions(table.Options), escapeTableCell(table.Description))
+	}
+
+	sb.WriteString("\n")
+
+	for i := range tables {
+		table := &tables[i]
+
+		if len(table.Columns) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(sb, "#### %s.%s\n\n", table.Schema, table.Name)
+
+		if table.Description != "" {
+			fmt.Fprintf(sb, "%s\n\n", table.Description)
+		}
+
+		r.writeViewColumns(sb, table.Columns)
+	}
+}
+
+// formatOptions renders FDW "name=value" options as inline code.
+func formatOptions(options []string) string {
+	formatted := make([]string, len(options))
+	for i, option := range options {
+		formatted[i] = "`" + escapeTableCell(option) + "`"
+	}
+
+	return strings.Join(formatted, ", ")
+}
+
+func (r *MarkdownReporter) writeSequences(sb *strings.Builder, sequences []models.Sequence) {
+	sb.WriteString("## Sequences\n\n")
 
-	for i := range tables {
-		totalRows += tables[i].RowCount
+	if len(sequences) == 0 {
+		sb.WriteString("No sequences are defined.\n\n")
+		return
+	}
+
+	r.writeSequenceAlerts(sb, sequences)
+
+	sb.WriteString("| Sequence | Schema | Data Type | Start | Min | Max | Increment | Last Value | Used | Owned By | Description |\n")
+	sb.WriteString("|----------|--------|-----------|-------|-----|-----|-----------|------------|------|----------|-------------|\n")
+
+	for i := range sequences {
+		r.writeSequence(sb, &sequences[i])
+	}
+
+	sb.WriteString("\n")
+}
+
+// writeSequenceAlerts calls out sequences that have used more of their range
+// than the configured thresholds, before they overflow their column.
+func (r *MarkdownReporter) writeSequenceAlerts(sb *strings.Builder, sequences []models.Sequence) {
+	var alerts []string
+
+	for i := range sequences {
+		seq := &sequences[i]
+
+		level := r.sequenceLevel(seq)
+		if level == "" {
+			continue
+		}
+
+		alert := fmt.Sprintf("> **%s:** `%s.%s`", level, seq.Schema, seq.Name)
+		if seq.OwnerTable != "" {
+			alert += fmt.Sprintf(" (feeding `%s.%s`)", seq.OwnerTable, seq.OwnerColumn)
+		}
+
+		alerts = append(alerts, alert+fmt.Sprintf(" has used %.1f%% of its range.", seq.PercentUsed))
+	}
+
+	if len(alerts) == 0 {
+		return
+	}
+
+	sb.WriteString(strings.Join(alerts, "\n>\n"))
+	sb.WriteString("\n\n")
+}
+
+// sequenceLevel returns "Critical" or "Warning" once a sequence crosses the
+// corresponding threshold, and "" otherwise.
+func (r *MarkdownReporter) sequenceLevel(seq *models.Sequence) string {
+	switch {
+	case seq.LastValue == nil:
+		return ""
+	case seq.PercentUsed >= r.sequenceCritical:
+		return "Critical"
+	case seq.PercentUsed >= r.sequenceWarning:
+		return "Warning"
+	default:
+		return ""
+	}
+}
+
+func (r *MarkdownReporter) writeSequence(sb *strings.Builder, seq *models.Sequence) {
+	sb.WriteString("| ")
+	sb.WriteString(seq.Name)
+	sb.WriteString(" | ")
+	sb.WriteString(seq.Schema)
+	sb.WriteString(" | ")
+	sb.WriteString(seq.DataType)
+	sb.WriteString(" | ")
+	fmt.Fprintf(sb, "%d", seq.StartValue)
+	sb.WriteString(" | ")
+	fmt.Fprintf(sb, "%d", seq.MinValue)
+	sb.WriteString(" | ")
+	fmt.Fprintf(sb, "%d", seq.MaxValue)
+	sb.WriteString(" | ")
+	fmt.Fprintf(sb, "%d", seq.Increment)
+	sb.WriteString(" | ")
+
+	if seq.LastValue != nil {
+		fmt.Fprintf(sb, "%d | %.1f%%", *seq.LastValue, seq.PercentUsed)
+
+		if level := r.sequenceLevel(seq); level != "" {
+			fmt.Fprintf(sb, " (%s)", strings.ToLower(level))
+		}
+	} else {
+		sb.WriteString(" | ")
+	}
+
+	sb.WriteString(" | ")
+
+	if seq.OwnerTable != "" {
+		fmt.Fprintf(sb, "%s.%s", seq.OwnerTable, seq.OwnerColumn)
+
+		if seq.IsIdentity {
+			sb.WriteString(" (identity)")
+		}
+	}
+
+	sb.WriteString(" | ")
+	sb.WriteString(escapeTableCell(seq.Description))
+	sb.WriteString(" |\n")
+}
+
+func (r *MarkdownReporter) writeRoutines(sb *strings.Builder, routines []models.Routine) {
+	sb.WriteString("## Functions\n\n")
+
+	sb.WriteString("| Routine | Schema | Kind | Returns | Language | Description |\n")
+	sb.WriteString("|---------|--------|------|---------|----------|-------------|\n")
+
+	for i := range routines {
+		routine := &routines[i]
+		fmt.Fprintf(sb, "| [%s(%s)](#%s) | %s | %s | %s | %s | %s |\n",
+			routine.Name, escapeTableCell(routine.Arguments), routineAnchor(routine), routine.Schema, routine.Kind,
+			escapeTableCell(routine.ReturnType), routine.Language, escapeTableCell(routine.Description))
+	}
+
+	sb.WriteString("\n")
+
+	for i := range routines {
+		r.writeRoutine(sb, &routines[i])
+	}
+}
+
+func (r *MarkdownReporter) writeRoutine(sb *strings.Builder, routine *models.Routine) {
+	fmt.Fprintf(sb, "### %s.%s(%s)\n\n", routine.Schema, routine.Name, routine.Arguments)
+	fmt.Fprintf(sb, "<a id=\"%s\"></a>\n\n", routineAnchor(routine))
+
+	if routine.Description != "" {
+		fmt.Fprintf(sb, "%s\n\n", routine.Description)
+	}
+
+	fmt.Fprintf(sb, "- **Kind:** %s\n", routine.Kind)
+
+	if routine.ReturnType != "" {
+		fmt.Fprintf(sb, "- **Returns:** `%s`\n", routine.ReturnType)
+	}
+
+	fmt.Fprintf(sb, "- **Language:** %s\n", routine.Language)
+	fmt.Fprintf(sb, "- **Volatility:** %s\n", routine.Volatility)
+
+	if routine.SecurityDefiner {
+		sb.WriteString("- **Security:** DEFINER\n")
+	} else {
+		sb.WriteString("- **Security:** INVOKER\n")
+	}
+
+	if routine.Owner != "" {
+		fmt.Fprintf(sb, "- **Owner:** %s\n", routine.Owner)
+	}
+
+	sb.WriteString("\n")
+
+	r.writeDefinition(sb, routine.Source)
+}
+
+// writePrivileges renders a role-by-object matrix of grants.
+func (r *MarkdownReporter) writePrivileges(sb *strings.Builder, grants []models.Grant) {
+	sb.WriteString("## Privileges\n\n")
+	sb.WriteString("Privileges use the `psql` `\\dp` abbreviations: r SELECT, a INSERT, w UPDATE, d DELETE, ")
+	sb.WriteString("D TRUNCATE, x REFERENCES, t TRIGGER, m MAINTAIN, X EXECUTE, U USAGE, C CREATE, c CONNECT, ")
+	sb.WriteString("T TEMPORARY. A `*` marks a privilege held WITH GRANT OPTION.\n\n")
+
+	matrix := buildPrivilegeMatrix(grants)
+
+	sb.WriteString("| Object | Type |")
+
+	for _, role := range matrix.Roles {
+		fmt.Fprintf(sb, " %s |", escapeTableCell(role))
+	}
+
+	sb.WriteString("\n|--------|------|")
+	sb.WriteString(strings.Repeat("------|", len(matrix.Roles)))
+	sb.WriteString("\n")
+
+	for i := range matrix.Objects {
+		object := &matrix.Objects[i]
+
+		name := object.Schema + "." + object.Object
+		if object.ObjectType == "schema" {
+			name = object.Schema
+		}
+
+		if object.Column != "" {
+			name += "." + object.Column
+		}
+
+		fmt.Fprintf(sb, "| %s | %s |", escapeTableCell(name), object.ObjectType)
+
+		for _, role := range matrix.Roles {
+			fmt.Fprintf(sb, " %s |", formatPrivileges(object.Grants[role]))
+		}
+
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("\n")
+}
+
+// summaryTablesLimit caps the number of tables listed under Largest Tables
+// and Hottest Tables.
+const summaryTablesLimit = 10
+
+// databaseEngine names the database product in the report title. Reports
+// built without an overview keep the PostgreSQL title.
+func databaseEngine(schema *models.Schema) string {
+	if schema.Database == nil || schema.Database.Engine == "" {
+		return "PostgreSQL"
+	}
+
+	return schema.Database.Engine
+}
+
+// writeSnapshot records the read-only transaction snapshot the report was
+// read from. MariaDB snapshots cannot be exported and have no ID.
+func writeSnapshot(sb *strings.Builder, snapshot *models.Snapshot) {
+	takenAt := snapshot.TakenAt.Format("2006-01-02 15:04:05 MST")
+
+	if snapshot.ID == "" {
+		fmt.Fprintf(sb, "Snapshot taken at: %s\n\n", takenAt)
+		return
+	}
+
+	fmt.Fprintf(sb, "Snapshot: `%s` taken at %s\n\n", snapshot.ID, takenAt)
+}
+
+// writeGaps lists the parts of the schema that could not be read, so that a
+// reader knows what is missing from the sections that follow.
+func writeGaps(sb *strings.Builder, warnings []models.Warning) {
+	sb.WriteString("## Gaps in this report\n\n")
+	sb.WriteString("Some objects could not be read, so the sections below are incomplete.\n\n")
+	sb.WriteString("| Reason | Detail |\n")
+	sb.WriteString("|--------|--------|\n")
+
+	for _, warning := range warnings {
+		fmt.Fprintf(sb, "| %s | %s |\n", warning.Reason, escapeTableCell(warning.Message))
+	}
+
+	sb.WriteString("\n")
+}
+
+// writeDatabaseOverview opens the report with the database's identity and
+// server configuration: properties, schemas with their owners and the
+// settings that differ from the server defaults.
+func (r *MarkdownReporter) writeDatabaseOverview(sb *strings.Builder, schema *models.Schema) {
+	info := schema.Database
+
+	properties := [][2]string{
+		{"Database", info.Name},
+		{"Server", strings.TrimSpace(info.Engine + " " + info.ServerVersion)},
+		{"Owner", info.Owner},
+		{"Encoding", info.Encoding},
+		{"Collation", info.Collation},
+		{"Ctype", info.CType},
+		{"Time Zone", info.TimeZone},
+	}
+
+	if schema.SizeBytes > 0 {
+		properties = append(properties, [2]string{"Size", formatBytes(schema.SizeBytes)})
+	}
+
+	if len(schema.Extensions) > 0 {
+		upgrades := 0
+
+		for i := range schema.Extensions {
+			if schema.Extensions[i].HasUpgrade() {
+				upgrades++
+			}
+		}
+
+		properties = append(properties, [2]string{
+			"Extensions", fmt.Sprintf("%d installed, %d with an upgrade available", len(schema.Extensions), upgrades),
+		})
+	}
+
+	sb.WriteString("| Property | Value |\n")
+	sb.WriteString("|----------|-------|\n")
+
+	for _, property := range properties {
+		if property[1] == "" {
+			continue
+		}
+
+		fmt.Fprintf(sb, "| %s | %s |\n", property[0], escapeTableCell(property[1]))
+	}
+
+	sb.WriteString("\n")
+
+	if len(info.Schemas) > 0 {
+		sb.WriteString("**Schemas:**\n\n")
+		sb.WriteString("| Schema | Owner | Description |\n")
+		sb.WriteString("|--------|-------|-------------|\n")
+
+		for _, s := range info.Schemas {
+			fmt.Fprintf(sb, "| %s | %s | %s |\n", s.Name, s.Owner, escapeTableCell(s.Description))
+		}
+
+		sb.WriteString("\n")
+	}
+
+	if len(info.Settings) > 0 {
+		sb.WriteString("**Non-default Settings:**\n\n")
+		sb.WriteString("| Setting | Value | Source |\n")
+		sb.WriteString("|---------|-------|--------|\n")
+
+		for _, setting := range info.Settings {
+			fmt.Fprintf(sb, "| %s | %s | %s |\n", setting.Name, escapeTableCell(setting.Value), setting.Source)
+		}
+
+		sb.WriteString("\n")
 	}
+}
+
+func (r *MarkdownReporter) writeDatabaseSummary(sb *strings.Builder, schema *models.Schema) {
+	sb.WriteString("## Database Summary\n\n")
+
+	tableCount := len(schema.Tables)
+	totalRows := totalRowCount(schema.Tables)
 
 	fmt.Fprintf(sb, "**Total Tables:** %d\n", tableCount)
 
@@ -333,5 +1562,246 @@ func (r *MarkdownReporter) writeDatabaseSummary(sb *strings.Builder, tables []mo
 		fmt.Fprintf(sb, "**Total Rows:** %d\n", totalRows)
 	}
 
+	if schema.SizeBytes > 0 {
+		fmt.Fprintf(sb, "**Database Size:** %s\n", formatBytes(schema.SizeBytes))
+	}
+
+	sb.WriteString("\n")
+
+	r.writeLargestTables(sb, schema.Tables)
+	r.writeHottestTables(sb, schema.Tables)
+}
+
+func (r *MarkdownReporter) writeLargestTables(sb *strings.Builder, tables []models.Table) {
+	largest := largestTables(tables, summaryTablesLimit)
+	if len(largest) == 0 {
+		return
+	}
+
+	sb.WriteString("### Largest Tables\n\n")
+	sb.WriteString("| Table | Rows | Total | Heap | TOAST | Indexes |\n")
+	sb.WriteString("|-------|------|-------|------|-------|---------|\n")
+
+	for _, table := range largest {
+		fmt.Fprintf(sb, "| %s.%s | %d | %s | %s | %s | %s |\n", table.Schema, table.Name, table.RowCount,
+			formatBytes(table.TotalSizeBytes), formatBytes(table.HeapSizeBytes),
+			formatBytes(table.ToastSizeBytes), formatBytes(table.IndexesSizeBytes))
+	}
+
+	sb.WriteString("\n")
+}
+
+// writeHottestTables ranks tables by rows written since statistics were last
+// reset, showing how they are read alongside.
+func (r *MarkdownReporter) writeHottestTables(sb *strings.Builder, tables []models.Table) {
+	hottest := hottestTables(tables, summaryTablesLimit)
+	if len(hottest) == 0 {
+		return
+	}
+
+	sb.WriteString("### Hottest Tables\n\n")
+	sb.WriteString("| Table | Rows Written | Inserts | Updates | Deletes | Seq Scans | Index Scans | Dead Tuples | Cache Hit |\n")
+	sb.WriteString("|-------|--------------|---------|---------|---------|-----------|-------------|-------------|-----------|\n")
+
+	for _, table := range hottest {
+		stats := table.Stats
+		fmt.Fprintf(sb, "| %s.%s | %d | %d | %d | %d | %d | %d | %d | %s |\n", table.Schema, table.Name,
+			rowsWritten(stats), stats.TuplesInserted, stats.TuplesUpdated, stats.TuplesDeleted,
+			stats.SeqScans, stats.IndexScans, stats.DeadTuples,
+			formatHitRatio(stats.HeapBlocksHit, stats.HeapBlocksRead))
+	}
+
 	sb.WriteString("\n")
 }
+
+// hottestTables returns up to limit tables with any recorded activity,
+// ordered by rows written and then by scans. Partitions are ranked on their
+// own since that is where statistics are kept.
+func hottestTables(tables []models.Table, limit int) []*models.Table {
+	var (
+		active  []*models.Table
+		collect func(tables []models.Table)
+	)
+
+	seen := make(map[string]bool)
+	collect = func(tables []models.Table) {
+		for i := range tables {
+			table := &tables[i]
+			key := table.Schema + "." + table.Name
+
+			if table.Stats != nil && !seen[key] && rowsWritten(table.Stats)+tableScans(table.Stats) > 0 {
+				seen[key] = true
+
+				active = append(active, table)
+			}
+
+			collect(table.Partitions)
+		}
+	}
+
+	collect(tables)
+
+	sort.SliceStable(active, func(i, j int) bool {
+		wi, wj := rowsWritten(active[i].Stats), rowsWritten(active[j].Stats)
+		if wi != wj {
+			return wi > wj
+		}
+
+		return tableScans(active[i].Stats) > tableScans(active[j].Stats)
+	})
+
+	if len(active) > limit {
+		active = active[:limit]
+	}
+
+	return active
+}
+
+func rowsWritten(stats *models.TableStats) int64 {
+	return stats.TuplesInserted + stats.TuplesUpdated + stats.TuplesDeleted
+}
+
+func tableScans(stats *models.TableStats) int64 {
+	return stats.SeqScans + stats.IndexScans
+}
+
+// formatStatsTime renders a maintenance timestamp, or "never" if it has not run.
+func formatStatsTime(t *time.Time) string {
+	if t == nil {
+		return "never"
+	}
+
+	return t.Format("2006-01-02 15:04:05")
+}
+
+// formatHitRatio renders the share of block requests served from the buffer
+// cache, or "n/a" when no blocks have been requested.
+func formatHitRatio(hit, read int64) string {
+	if hit+read == 0 {
+		return "n/a"
+	}
+
+	return fmt.Sprintf("%.1f%%", float64(hit)*100/float64(hit+read))
+}
+
+// largestTables returns up to limit tables ordered by total size, largest
+// first. Partitions listed next to their parent are left out because the
+// parent's size already includes them.
+func largestTables(tables []models.Table, limit int) []*models.Table {
+	var sized []*models.Table
+
+	for _, table := range topLevelTables(tables) {
+		if table.TotalSizeBytes > 0 {
+			sized = append(sized, table)
+		}
+	}
+
+	sort.SliceStable(sized, func(i, j int) bool {
+		return sized[i].TotalSizeBytes > sized[j].TotalSizeBytes
+	})
+
+	if len(sized) > limit {
+		sized = sized[:limit]
+	}
+
+	return sized
+}
+
+// escapeTableCell keeps free-form SQL text from breaking a markdown table row.
+func escapeTableCell(text string) string {
+	text = strings.ReplaceAll(text, "|", "\\|")
+	text = strings.ReplaceAll(text, "\r\n", " ")
+
+	return strings.ReplaceAll(text, "\n", " ")
+}
+
+// formatExpression renders a SQL expression as inline code in a table cell,
+// leaving the cell blank when there is no expression.
+func formatExpression(expression string) string {
+	if expression == "" {
+		return ""
+	}
+
+	return "`" + escapeTableCell(expression) + "`"
+}
+
+// formatBytes renders a byte count the way pg_size_pretty does.
+func formatBytes(size int64) string {
+	units := []string{"bytes", "kB", "MB", "GB", "TB", "PB"}
+	value := float64(size)
+	unit := 0
+
+	for value >= 1024 && unit < len(units)-1 {
+		value /= 1024
+		unit++
+	}
+
+	if unit == 0 {
+		return fmt.Sprintf("%d %s", size, units[0])
+	}
+
+	return fmt.Sprintf("%.1f %s", value, units[unit])
+}
+
+// totalRowCount sums row counts without double-counting partitions that are
+// listed next to their parent, since a parent already reports their sum.
+func totalRowCount(tables []models.Table) int64 {
+	var totalRows int64
+
+	for _, table := range topLevelTables(tables) {
+		totalRows += table.RowCount
+	}
+
+	return totalRows
+}
+
+// topLevelTables skips partitions that are listed next to their parent, whose
+// row counts and sizes already include them.
+func topLevelTables(tables []models.Table) []*models.Table {
+	documented := make(map[string]bool, len(tables))
+	for i := range tables {
+		documented[tables[i].Schema+"."+tables[i].Name] = true
+	}
+
+	topLevel := make([]*models.Table, 0, len(tables))
+
+	for i := range tables {
+		if tables[i].PartitionOf != "" && documented[tables[i].PartitionOf] {
+			continue
+		}
+
+		topLevel = append(topLevel, &tables[i])
+	}
+
+	return topLevel
+}
+
+func typeAnchor(schema, name string) string {
+	return "type-" + anchorSlug(schema+"."+name)
+}
+
+// routineAnchor includes the argument list because overloaded routines share
+// a name.
+func routineAnchor(routine *models.Routine) string {
+	anchor := "function-" + anchorSlug(routine.Schema+"."+routine.Name)
+	if routine.Arguments != "" {
+		anchor += "-" + anchorSlug(routine.Arguments)
+	}
+
+	return anchor
+}
+
+// routineKey identifies a routine by its qualified name and identity arguments.
+func routineKey(schema, name, arguments string) string {
+	return schema + "." + name + "(" + arguments + ")"
+}
+
+func anchorSlug(text string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' {
+			return r
+		}
+
+		return '-'
+	}, strings.ToLower(text))
+}
@@ -3,6 +3,7 @@ package reporter
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/orchard9/pg-goer/pkg/models"
 )
@@ -98,11 +99,11 @@ func TestGenerateMarkdown(t *testing.T) {
 						},
 						ForeignKeys: []models.ForeignKey{
 							{
-								Name:             "fk_orders_user_id",
-								SourceTable:      "orders",
-								SourceColumn:     "user_id",
-								ReferencedTable:  "public.users",
-								ReferencedColumn: "id",
+								Name:              "fk_orders_user_id",
+								SourceTable:       "orders",
+								SourceColumns:     []string{"user_id"},
+								ReferencedTable:   "public.users",
+								ReferencedColumns: []string{"id"},
 							},
 						},
 					},
@@ -119,6 +120,875 @@ func TestGenerateMarkdown(t *testing.T) {
 				"## orders",
 			},
 		},
+		{
+			name: "composite foreign key listed once",
+			schema: models.Schema{
+				Name: "public",
+				Tables: []models.Table{
+					{
+						Schema: "public",
+						Name:   "shipments",
+						Columns: []models.Column{
+							{Name: "order_id", DataType: "integer"},
+							{Name: "line_no", DataType: "integer"},
+						},
+						ForeignKeys: []models.ForeignKey{
+							{
+								Name:              "fk_shipments_order_line",
+								SourceTable:       "shipments",
+								SourceColumns:     []string{"order_id", "line_no"},
+								ReferencedTable:   "public.order_lines",
+								ReferencedColumns: []string{"order_id", "line_no"},
+								OnDelete:          "CASCADE",
+								OnUpdate:          "NO ACTION",
+							},
+						},
+					},
+				},
+			},
+			expectContains: []string{
+				"### Foreign Keys",
+				"| fk_shipments_order_line | order_id, line_no | public.order_lines(order_id, line_no) | CASCADE | NO ACTION |",
+			},
+		},
+		{
+			name: "table constraints",
+			schema: models.Schema{
+				Name: "public",
+				Tables: []models.Table{
+					{
+						Schema: "public",
+						Name:   "bookings",
+						Columns: []models.Column{
+							{Name: "room_id", DataType: "integer"},
+							{Name: "during", DataType: "tsrange"},
+						},
+						Constraints: []models.Constraint{
+							{
+								Name:        "bookings_no_overlap",
+								Type:        "EXCLUDE",
+								Columns:     []string{"room_id", "during"},
+								Definition:  "EXCLUDE USING gist (room_id WITH =, during WITH &&)",
+								IsValidated: true,
+							},
+							{
+								Name:         "bookings_room_fk",
+								Type:         "FOREIGN KEY",
+								Columns:      []string{"room_id"},
+								Definition:   "FOREIGN KEY (room_id) REFERENCES rooms(id) DEFERRABLE INITIALLY DEFERRED",
+								IsDeferrable: true,
+								IsDeferred:   true,
+								IsValidated:  true,
+							},
+							{
+								Name:       "bookings_label_check",
+								Type:       "CHECK",
+								Definition: "CHECK (label::text <> ''::text OR label IS NULL) NOT VALID",
+							},
+						},
+					},
+				},
+			},
+			expectContains: []string{
+				"### Constraints",
+				"| bookings_no_overlap | EXCLUDE | room_id, during | `EXCLUDE USING gist (room_id WITH =, during WITH &&)` | NO | YES |",
+				"| bookings_room_fk | FOREIGN KEY | room_id | `FOREIGN KEY (room_id) REFERENCES rooms(id) DEFERRABLE INITIALLY DEFERRED` | DEFERRABLE INITIALLY DEFERRED | YES |",
+				"| bookings_label_check | CHECK |  | `CHECK (label::text <> ''::text OR label IS NULL) NOT VALID` | NO | NO (NOT VALID) |",
+			},
+		},
+		{
+			name: "comments from COMMENT ON",
+			schema: models.Schema{
+				Name: "public",
+				Tables: []models.Table{
+					{
+						Schema:      "public",
+						Name:        "customers",
+						Description: "Paying customers, one row per billing account.",
+						Columns: []models.Column{
+							{Name: "id", DataType: "integer", IsPrimaryKey: true, Description: "Billing account id"},
+							{Name: "tier", DataType: "text", Description: "gold | silver"},
+						},
+					},
+				},
+				Views: []models.View{
+					{Schema: "public", Name: "active_customers", Description: "Customers with an open contract"},
+				},
+			},
+			expectContains: []string{
+				"Paying customers, one row per billing account.",
+				"| Column | Type | Nullable | Constraints | Default | Description |",
+				"| id | integer | NO | PRIMARY KEY |  | Billing account id |",
+				"| tier | text | NO |  |  | gold \\| silver |",
+				"| active_customers | public | Customers with an open contract |",
+			},
+		},
+		{
+			name: "view definition, columns and dependencies",
+			schema: models.Schema{
+				Name: "public",
+				Tables: []models.Table{
+					{
+						Schema:  "public",
+						Name:    "orders",
+						Columns: []models.Column{{Name: "total", DataType: "numeric"}},
+					},
+				},
+				Views: []models.View{
+					{
+						Schema:     "public",
+						Name:       "order_totals",
+						Definition: " SELECT sum(orders.total) AS total\n   FROM orders;",
+						Columns: []models.Column{
+							{Name: "total", DataType: "numeric", IsNullable: true},
+						},
+						Dependencies: []models.Dependency{
+							{Schema: "public", Name: "orders", Kind: "table"},
+						},
+					},
+				},
+			},
+			expectContains: []string{
+				"- [View Dependencies](#view-dependencies)",
+				"## View Dependencies",
+				"flowchart LR",
+				"public__orders --> public__order_totals",
+				"### public.order_totals",
+				"| total | numeric | YES |  |",
+				"**Depends on:** `public.orders` (table)",
+				"```sql\nSELECT sum(orders.total) AS total\n   FROM orders;\n```",
+			},
+		},
+		{
+			name: "materialized views",
+			schema: models.Schema{
+				Name: "public",
+				Tables: []models.Table{
+					{
+						Schema:  "public",
+						Name:    "events",
+						Columns: []models.Column{{Name: "id", DataType: "bigint"}},
+					},
+				},
+				MaterializedViews: []models.MaterializedView{
+					{
+						Schema:      "reporting",
+						Name:        "daily_events",
+						Definition:  "SELECT count(*) AS n FROM events",
+						IsPopulated: false,
+						SizeBytes:   16384,
+						Columns: []models.Column{
+							{Name: "n", DataType: "bigint", IsNullable: true},
+						},
+						Indexes: []models.Index{
+							{Name: "daily_events_n_idx", Type: "INDEX", Columns: []string{"n"}, Method: "btree"},
+						},
+						Dependencies: []models.Dependency{
+							{Schema: "public", Name: "events", Kind: "table"},
+						},
+					},
+				},
+			},
+			expectContains: []string{
+				"- [Materialized Views](#materialized-views)",
+				"## Materialized Views",
+				"| daily_events | reporting | NO | 16.0 kB |  |",
+				"### reporting.daily_events",
+				"> Not populated",
+				"| daily_events_n_idx | INDEX | n | btree |",
+				"**Depends on:** `public.events` (table)",
+				`reporting__daily_events[("reporting.daily_events")]`,
+				"public__events --> reporting__daily_events",
+			},
+		},
+		{
+			name: "partitioned table collapses its partitions",
+			schema: models.Schema{
+				Name: "public",
+				Tables: []models.Table{
+					{
+						Schema:            "public",
+						Name:              "events",
+						PartitionStrategy: "RANGE",
+						PartitionKey:      "created_at",
+						RowCount:          30,
+						Columns:           []models.Column{{Name: "created_at", DataType: "timestamp with time zone"}},
+						Partitions: []models.Table{
+							{
+								Schema:         "public",
+								Name:           "events_2024",
+								PartitionOf:    "public.events",
+								PartitionBound: "FOR VALUES FROM ('2024-01-01') TO ('2025-01-01')",
+								RowCount:       30,
+							},
+						},
+					},
+				},
+			},
+			expectContains: []string{
+				"Partitioned by: `RANGE (created_at)`",
+				"### Partitions (1)",
+				"| public.events_2024 | `FOR VALUES FROM ('2024-01-01') TO ('2025-01-01')` | 30 |",
+				"**Total Rows:** 30",
+			},
+		},
+		{
+			name: "expanded partitions are not double counted",
+			schema: models.Schema{
+				Name: "public",
+				Tables: []models.Table{
+					{
+						Schema:            "public",
+						Name:              "events",
+						PartitionStrategy: "LIST",
+						PartitionKey:      "region",
+						RowCount:          7,
+					},
+					{
+						Schema:         "public",
+						Name:           "events_eu",
+						PartitionOf:    "public.events",
+						PartitionBound: "FOR VALUES IN ('eu')",
+						RowCount:       7,
+					},
+				},
+			},
+			expectContains: []string{
+				"Partition of: `public.events` `FOR VALUES IN ('eu')`",
+				"**Total Tables:** 2",
+				"**Total Rows:** 7",
+			},
+		},
+		{
+			name: "user-defined types linked from columns",
+			schema: models.Schema{
+				Name: "public",
+				Tables: []models.Table{
+					{
+						Schema: "public",
+						Name:   "people",
+						Columns: []models.Column{
+							{Name: "current_mood", DataType: "public.mood", TypeRef: "public.mood"},
+						},
+					},
+				},
+				Types: []models.Type{
+					{Schema: "public", Name: "mood", Kind: "enum", Labels: []string{"sad", "ok", "happy"}},
+					{Schema: "public", Name: "us_postal_code", Kind: "domain", BaseType: "text", NotNull: true, Constraints: []string{"CHECK (VALUE ~ '^\\d{5}$'::text)"}},
+					{Schema: "public", Name: "address", Kind: "composite", Attributes: []models.TypeAttribute{{Name: "street", DataType: "text"}}},
+					{Schema: "public", Name: "price_range", Kind: "range", Subtype: "numeric"},
+				},
+			},
+			expectContains: []string{
+				"## Types",
+				"- [Types](#types)",
+				"**Values:** `sad`, `ok`, `happy`",
+				"**Base type:** `text` NOT NULL",
+				"- `CHECK (VALUE ~ '^\\d{5}$'::text)`",
+				"| street | text |",
+				"**Subtype:** `numeric`",
+				"| current_mood | [public.mood](#type-public-mood) |",
+			},
+		},
+		{
+			name: "functions linked from triggers",
+			schema: models.Schema{
+				Name: "public",
+				Tables: []models.Table{
+					{
+						Schema:  "public",
+						Name:    "orders",
+						Columns: []models.Column{{Name: "id", DataType: "integer", IsPrimaryKey: true}},
+						Triggers: []models.Trigger{
+							{Name: "orders_touch", Event: "UPDATE", Timing: "BEFORE", Function: "touch_updated_at", FunctionSchema: "public", Orientation: "ROW"},
+						},
+					},
+				},
+				Routines: []models.Routine{
+					{
+						Schema: "public", Name: "touch_updated_at", Kind: "function", ReturnType: "trigger",
+						Language: "plpgsql", Volatility: "VOLATILE", Owner: "app",
+						Source: "BEGIN NEW.updated_at := now(); RETURN NEW; END;",
+					},
+					{
+						Schema: "billing", Name: "charge", Kind: "procedure", Arguments: "customer_id integer, amount numeric",
+						Language: "sql", Volatility: "VOLATILE", SecurityDefiner: true, Description: "Charges a customer",
+					},
+				},
+			},
+			expectContains: []string{
+				"- [Functions](#functions)",
+				"## Functions",
+				"| [touch_updated_at()](#function-public-touch-updated-at) | public | function | trigger | plpgsql |  |",
+				"### billing.charge(customer_id integer, amount numeric)",
+				`<a id="function-billing-charge-customer-id-integer--amount-numeric"></a>`,
+				"- **Security:** DEFINER",
+				"- **Owner:** app",
+				"```sql\nBEGIN NEW.updated_at := now(); RETURN NEW; END;\n```",
+				"| orders_touch | UPDATE | BEFORE | [touch_updated_at](#function-public-touch-updated-at) | ROW |",
+			},
+		},
+		{
+			name: "row-level security policies",
+			schema: models.Schema{
+				Name: "public",
+				Tables: []models.Table{
+					{
+						Schema:           "public",
+						Name:             "documents",
+						RowSecurity:      true,
+						ForceRowSecurity: true,
+						Columns:          []models.Column{{Name: "tenant_id", DataType: "integer"}},
+						Policies: []models.Policy{
+							{
+								Name:         "tenant_isolation",
+								Command:      "ALL",
+								IsPermissive: true,
+								Roles:        []string{"app_user"},
+								Using:        "(tenant_id = current_setting('app.tenant_id')::integer)",
+								WithCheck:    "(tenant_id = current_setting('app.tenant_id')::integer)",
+							},
+							{Name: "no_archived", Command: "SELECT", Roles: []string{"public"}, Using: "(NOT archived)"},
+						},
+					},
+					{
+						Schema:      "public",
+						Name:        "secrets",
+						RowSecurity: true,
+						Columns:     []models.Column{{Name: "id", DataType: "integer"}},
+					},
+				},
+			},
+			expectContains: []string{
+				"### Row-Level Security",
+				"Row-level security: **enabled** (forced for the table owner)",
+				"| tenant_isolation | ALL | PERMISSIVE | app_user | `(tenant_id = current_setting('app.tenant_id')::integer)` | `(tenant_id = current_setting('app.tenant_id')::integer)` |",
+				"| no_archived | SELECT | RESTRICTIVE | public | `(NOT archived)` |  |",
+				"Row-level security: **enabled**\n\nNo policies are defined",
+			},
+		},
+		{
+			name: "privilege matrix",
+			schema: models.Schema{
+				Name: "public",
+				Tables: []models.Table{
+					{Schema: "public", Name: "users", Columns: []models.Column{{Name: "email", DataType: "text"}}},
+				},
+				Grants: []models.Grant{
+					{ObjectType: "schema", Schema: "public", Object: "public", Grantee: "app", Privilege: "USAGE"},
+					{ObjectType: "table", Schema: "public", Object: "users", Grantee: "app", Privilege: "SELECT", IsGrantable: true},
+					{ObjectType: "table", Schema: "public", Object: "users", Grantee: "app", Privilege: "INSERT"},
+					{ObjectType: "column", Schema: "public", Object: "users", Column: "email", Grantee: "support", Privilege: "SELECT"},
+				},
+			},
+			expectContains: []string{
+				"- [Privileges](#privileges)",
+				"## Privileges",
+				"| Object | Type | app | support |",
+				"|--------|------|------|------|",
+				"| public | schema | U |  |",
+				"| public.users | table | ar* |  |",
+				"| public.users.email | column |  | r |",
+			},
+		},
+		{
+			name: "index key parts, include columns and predicates",
+			schema: models.Schema{
+				Name: "public",
+				Tables: []models.Table{
+					{
+						Schema:  "public",
+						Name:    "accounts",
+						Columns: []models.Column{{Name: "email", DataType: "text"}},
+						Indexes: []models.Index{
+							{
+								Name:       "accounts_lower_email_idx",
+								Type:       "UNIQUE",
+								Columns:    []string{"lower(email)", "created_at"},
+								Method:     "btree",
+								Definition: "CREATE UNIQUE INDEX accounts_lower_email_idx ON public.accounts USING btree (lower(email) text_pattern_ops, created_at DESC) INCLUDE (id) WHERE (deleted_at IS NULL)",
+								KeyParts: []models.IndexKeyPart{
+									{Expression: "lower(email)", OpClass: "text_pattern_ops"},
+									{Column: "created_at", Descending: true, NullsFirst: true},
+								},
+								Include:   []string{"id"},
+								Predicate: "deleted_at IS NULL",
+								IsValid:   true,
+							},
+							{
+								Name:     "accounts_name_idx",
+								Type:     "INDEX",
+								Columns:  []string{"name"},
+								Method:   "btree",
+								KeyParts: []models.IndexKeyPart{{Column: "name", NullsFirst: true}},
+							},
+						},
+					},
+				},
+			},
+			expectContains: []string{
+				"| Name | Type | Columns | Method | Include | Predicate | Valid |",
+				"| accounts_lower_email_idx | UNIQUE | lower(email) text_pattern_ops, created_at DESC | btree | id | `deleted_at IS NULL` | YES |",
+				"| accounts_name_idx | INDEX | name NULLS FIRST | btree |  |  | NO |",
+				"```sql\nCREATE UNIQUE INDEX accounts_lower_email_idx ON public.accounts USING btree (lower(email) text_pattern_ops, created_at DESC) INCLUDE (id) WHERE (deleted_at IS NULL);\n```",
+			},
+		},
+		{
+			name: "row count method and confidence",
+			schema: models.Schema{
+				Name: "public",
+				Tables: []models.Table{
+					{
+						Schema:             "public",
+						Name:               "events",
+						RowCount:           120000,
+						RowCountMethod:     models.RowCountSample,
+						RowCountConfidence: models.ConfidenceMedium,
+						Columns:            []models.Column{{Name: "id", DataType: "bigint"}},
+					},
+					{
+						Schema:             "public",
+						Name:               "archive",
+						RowCountMethod:     models.RowCountExact,
+						RowCountConfidence: models.ConfidenceHigh,
+						Columns:            []models.Column{{Name: "id", DataType: "bigint"}},
+					},
+				},
+			},
+			expectContains: []string{
+				"Row Count: 120000 (sample, medium confidence)",
+				"Row Count: 0 (exact, high confidence)",
+			},
+		},
+		{
+			name: "storage sizes and largest tables",
+			schema: models.Schema{
+				Name:      "public",
+				SizeBytes: 3 * 1024 * 1024 * 1024,
+				Tables: []models.Table{
+					{
+						Schema:           "public",
+						Name:             "small",
+						RowCount:         10,
+						HeapSizeBytes:    8192,
+						IndexesSizeBytes: 16384,
+						TotalSizeBytes:   24576,
+						Columns:          []models.Column{{Name: "id", DataType: "bigint"}},
+					},
+					{
+						Schema:           "public",
+						Name:             "documents",
+						RowCount:         5000,
+						HeapSizeBytes:    1024 * 1024,
+						ToastSizeBytes:   2 * 1024 * 1024,
+						IndexesSizeBytes: 512 * 1024,
+						TotalSizeBytes:   3584 * 1024,
+						Columns:          []models.Column{{Name: "id", DataType: "bigint"}},
+						Indexes: []models.Index{
+							{Name: "documents_pkey", Type: "PRIMARY KEY", IsPrimary: true, IsUnique: true, Columns: []string{"id"}, Method: "btree", IsValid: true, SizeBytes: 512 * 1024},
+						},
+					},
+				},
+			},
+			expectContains: []string{
+				"**Database Size:** 3.0 GB",
+				"### Largest Tables",
+				"| Table | Rows | Total | Heap | TOAST | Indexes |",
+				"| public.documents | 5000 | 3.5 MB | 1.0 MB | 2.0 MB | 512.0 kB |\n| public.small | 10 | 24.0 kB | 8.0 kB | 0 bytes | 16.0 kB |",
+				"Size: 3.5 MB (heap 1.0 MB, TOAST 2.0 MB, indexes 512.0 kB)",
+				"| documents_pkey | PRIMARY KEY | id | btree |  |  | YES | 512.0 kB |",
+			},
+		},
+		{
+			name: "column type modifiers, identity and generated columns",
+			schema: models.Schema{
+				Name: "public",
+				Tables: []models.Table{
+					{
+						Schema: "public",
+						Name:   "invoices",
+						Columns: []models.Column{
+							{Name: "id", DataType: "bigint", IsPrimaryKey: true, Identity: models.IdentityAlways},
+							{Name: "legacy_id", DataType: "int", Identity: models.IdentityAutoIncrement},
+							{Name: "amount", DataType: "numeric", Precision: intPtr(12), Scale: intPtr(2)},
+							{Name: "ratio", DataType: "numeric"},
+							{Name: "issued_at", DataType: "timestamp with time zone", Precision: intPtr(3)},
+							{Name: "created", DataType: "datetime", Precision: intPtr(6)},
+							{Name: "grid", DataType: "integer[]", ArrayDimensions: 2},
+							{Name: "code", DataType: "character varying", MaxLength: intPtr(10), Collation: "C"},
+							{Name: "total", DataType: "numeric", Generated: "STORED", GenerationExpression: "amount * 1.2"},
+						},
+					},
+				},
+			},
+			expectContains: []string{
+				"| id | bigint | NO | PRIMARY KEY, IDENTITY ALWAYS |  |",
+				"| legacy_id | int | NO | AUTO_INCREMENT |  |",
+				"| amount | numeric(12,2) | NO |",
+				"| ratio | numeric | NO |",
+				"| issued_at | timestamp(3) with time zone | NO |",
+				"| created | datetime(6) | NO |",
+				"| grid | integer[][] | NO |",
+				`| code | character varying(10) COLLATE "C" | NO |`,
+				"| total | numeric | NO |  | `GENERATED ALWAYS AS (amount * 1.2) STORED` |",
+			},
+		},
+		{
+			name: "trigger conditions, states and view triggers",
+			schema: models.Schema{
+				Name: "public",
+				Tables: []models.Table{
+					{
+						Schema:  "public",
+						Name:    "orders",
+						Columns: []models.Column{{Name: "id", DataType: "integer"}},
+						Triggers: []models.Trigger{
+							{
+								Name: "orders_audit", Event: "INSERT,UPDATE", Timing: "AFTER", Function: "audit", FunctionSchema: "public",
+								Orientation: "ROW", Columns: []string{"status", "total"}, Condition: "old.status IS DISTINCT FROM new.status",
+								Enabled:    models.TriggerEnabled,
+								Definition: "CREATE TRIGGER orders_audit AFTER INSERT OR UPDATE OF status, total ON public.orders FOR EACH ROW WHEN (old.status IS DISTINCT FROM new.status) EXECUTE FUNCTION audit()",
+							},
+							{
+								Name: "orders_truncate", Event: "TRUNCATE", Timing: "BEFORE", Function: "block_truncate", FunctionSchema: "public",
+								Orientation: "STATEMENT", Enabled: models.TriggerDisabled,
+							},
+							{
+								Name: "orders_batch", Event: "INSERT", Timing: "AFTER", Function: "summarize", FunctionSchema: "public",
+								Orientation: "STATEMENT", NewTable: "inserted", Enabled: models.TriggerReplica,
+							},
+							{
+								Name: "orders_check", Event: "UPDATE", Timing: "AFTER", Function: "check_totals", FunctionSchema: "public",
+								Orientation: "ROW", IsConstraint: true, Enabled: models.TriggerAlways,
+							},
+							{
+								Name: "orders_stamp", Event: "INSERT", Timing: "BEFORE", Orientation: "ROW", ActionOrder: 2,
+								Enabled: models.TriggerEnabled,
+							},
+						},
+					},
+				},
+				Views: []models.View{
+					{
+						Schema: "public",
+						Name:   "order_summary",
+						Triggers: []models.Trigger{
+							{Name: "order_summary_insert", Event: "INSERT", Timing: "INSTEAD OF", Function: "insert_order", FunctionSchema: "public", Orientation: "ROW", Enabled: models.TriggerEnabled},
+						},
+					},
+				},
+			},
+			expectContains: []string{
+				"| Name | Event | Timing | Function | Orientation | Enabled | Condition | Transition Tables | Order |",
+				"| orders_audit | INSERT,UPDATE OF status, total | AFTER | audit | ROW | ENABLED | `old.status IS DISTINCT FROM new.status` |  |  |",
+				"| orders_truncate | TRUNCATE | BEFORE | block_truncate | STATEMENT | DISABLED |  |  |  |",
+				"| orders_batch | INSERT | AFTER | summarize | STATEMENT | REPLICA |  | NEW TABLE AS inserted |  |",
+				"| orders_check (constraint) | UPDATE | AFTER | check_totals | ROW | ALWAYS |  |  |  |",
+				"| orders_stamp | INSERT | BEFORE |  | ROW | ENABLED |  |  | 2 |",
+				"```sql\nCREATE TRIGGER orders_audit AFTER INSERT OR UPDATE OF status, total ON public.orders FOR EACH ROW WHEN (old.status IS DISTINCT FROM new.status) EXECUTE FUNCTION audit();\n```",
+				"### public.order_summary",
+				"**Triggers:**",
+				"| order_summary_insert | INSERT | INSTEAD OF | insert_order | ROW | ENABLED |  |  |  |",
+			},
+		},
+		{
+			name: "database-level objects and table publications",
+			schema: models.Schema{
+				Name: "public",
+				Tables: []models.Table{
+					{Schema: "public", Name: "orders", Columns: []models.Column{{Name: "id", DataType: "integer"}}, Publications: []string{"cdc", "everything"}},
+				},
+				Routines: []models.Routine{
+					{Schema: "audit", Name: "log_ddl", Kind: "function", ReturnType: "event_trigger", Language: "plpgsql"},
+				},
+				EventTriggers: []models.EventTrigger{
+					{Name: "audit_ddl", Event: "ddl_command_end", Tags: []string{"CREATE TABLE", "ALTER TABLE"}, Function: "log_ddl", FunctionSchema: "audit", Enabled: models.TriggerEnabled, Owner: "postgres"},
+					{Name: "block_drops", Event: "sql_drop", Function: "deny_drop", FunctionSchema: "audit", Enabled: models.TriggerDisabled, Owner: "postgres"},
+				},
+				Rules: []models.Rule{
+					{Schema: "public", Table: "orders", Name: "orders_no_delete", Event: "DELETE", IsInstead: true, Enabled: models.TriggerEnabled,
+						Definition: "CREATE RULE orders_no_delete AS ON DELETE TO public.orders DO INSTEAD NOTHING;"},
+				},
+				Publications: []models.Publication{
+					{Name: "cdc", Owner: "postgres", Operations: []string{"INSERT", "UPDATE", "DELETE"}, Tables: []string{"public.orders"}},
+					{Name: "everything", Owner: "postgres", AllTables: true, Operations: []string{"INSERT", "UPDATE", "DELETE", "TRUNCATE"}, Tables: []string{"public.orders"}},
+				},
+			},
+			expectContains: []string{
+				"- [Database-level Objects](#database-level-objects)",
+				"## Database-level Objects",
+				"### Event Triggers",
+				"| audit_ddl | ddl_command_end | CREATE TABLE, ALTER TABLE | [log_ddl](#function-audit-log-ddl) | ENABLED | postgres |  |",
+				"| block_drops | sql_drop | all commands | deny_drop | DISABLED | postgres |  |",
+				"### Rules",
+				"| orders_no_delete | public.orders | DELETE | INSTEAD | ENABLED |",
+				"```sql\nCREATE RULE orders_no_delete AS ON DELETE TO public.orders DO INSTEAD NOTHING;\n```",
+				"### Publications",
+				"| cdc | postgres | INSERT, UPDATE, DELETE | public.orders |",
+				"| everything | postgres | INSERT, UPDATE, DELETE, TRUNCATE | all tables |",
+				"Publications: `cdc`, `everything`",
+			},
+		},
+		{
+			name: "foreign data wrappers, servers and tables",
+			schema: models.Schema{
+				Name: "public",
+				Tables: []models.Table{
+					{Schema: "public", Name: "customers", Columns: []models.Column{{Name: "id", DataType: "integer"}}},
+				},
+				ForeignDataWrappers: []models.ForeignDataWrapper{
+					{Name: "postgres_fdw", Owner: "postgres", Handler: "postgres_fdw_handler", Validator: "postgres_fdw_validator"},
+				},
+				ForeignServers: []models.ForeignServer{
+					{
+						Name: "sales", Wrapper: "postgres_fdw", Owner: "postgres",
+						Options:      []string{"host=sales.internal", "dbname=sales"},
+						UserMappings: []models.UserMapping{{User: "reporter", Options: []string{"user", "password"}}},
+					},
+				},
+				ForeignTables: []models.ForeignTable{
+					{
+						Schema: "remote", Name: "invoices", Server: "sales", Description: "Invoices from the sales database",
+						Options: []string{"schema_name=public", "table_name=invoices"},
+						Columns: []models.Column{{Name: "id", DataType: "integer"}, {Name: "total", DataType: "numeric", IsNullable: true}},
+					},
+				},
+			},
+			expectContains: []string{
+				"- [Foreign Data](#foreign-data)",
+				"## Foreign Data",
+				"### Foreign Data Wrappers",
+				"| postgres_fdw | postgres_fdw_handler | postgres_fdw_validator | postgres |  |",
+				"### Foreign Servers",
+				"| sales | postgres_fdw |  |  | postgres | `host=sales.internal`, `dbname=sales` |",
+				"**User Mappings:**",
+				"| sales | reporter | `user`, `password` |",
+				"### Foreign Tables",
+				"| invoices | remote | sales | `schema_name=public`, `table_name=invoices` | Invoices from the sales database |",
+				"#### remote.invoices",
+				"| total | numeric | YES |  |",
+				"- [Database Relationships](#database-relationships)",
+				"%% foreign table on server sales\n    invoices {",
+			},
+		},
+		{
+			name: "database overview header",
+			schema: models.Schema{
+				Name: "shop",
+				Database: &models.DatabaseInfo{
+					Engine: "PostgreSQL", ServerVersion: "16.2", Name: "shop", Owner: "app",
+					Encoding: "UTF8", Collation: "en_US.UTF-8", CType: "en_US.UTF-8", TimeZone: "UTC",
+					Settings: []models.Setting{{Name: "shared_buffers", Value: "1024 MB", Source: "configuration file"}},
+					Schemas:  []models.SchemaInfo{{Name: "public", Owner: "app", Description: "standard public schema"}},
+				},
+				Extensions: []models.Extension{
+					{Name: "pgcrypto", Version: "1.3", Schema: "public", DefaultVersion: "1.3"},
+					{Name: "postgis", Version: "3.3.2", Schema: "public", DefaultVersion: "3.4.0"},
+				},
+				SizeBytes: 1048576,
+				Tables: []models.Table{
+					{Schema: "public", Name: "users", Columns: []models.Column{{Name: "id", DataType: "integer"}}},
+				},
+			},
+			expectContains: []string{
+				"# PostgreSQL Database Documentation",
+				"| Database | shop |",
+				"| Server | PostgreSQL 16.2 |",
+				"| Owner | app |",
+				"| Encoding | UTF8 |",
+				"| Ctype | en_US.UTF-8 |",
+				"| Time Zone | UTC |",
+				"| Size | 1.0 MB |",
+				"| Extensions | 2 installed, 1 with an upgrade available |",
+				"**Schemas:**",
+				"| public | app | standard public schema |",
+				"**Non-default Settings:**",
+				"| shared_buffers | 1024 MB | configuration file |",
+				"| Extension | Version | Schema | Upgrade Available |",
+				"| pgcrypto | 1.3 | public |  |",
+				"| postgis | 3.3.2 | public | 3.4.0 |",
+			},
+		},
+		{
+			name: "mariadb title",
+			schema: models.Schema{
+				Name:     "shop",
+				Database: &models.DatabaseInfo{Engine: "MariaDB", ServerVersion: "11.4.2-MariaDB", Name: "shop", Encoding: "utf8mb4"},
+				Tables: []models.Table{
+					{Schema: "shop", Name: "users", Columns: []models.Column{{Name: "id", DataType: "int"}}},
+				},
+			},
+			expectContains: []string{
+				"# MariaDB Database Documentation",
+				"| Server | MariaDB 11.4.2-MariaDB |",
+			},
+		},
+		{
+			name: "mysql functional and descending index keys",
+			schema: models.Schema{
+				Name:     "shop",
+				Database: &models.DatabaseInfo{Engine: "MySQL", ServerVersion: "8.4.0", Name: "shop"},
+				Tables: []models.Table{
+					{
+						Schema:  "shop",
+						Name:    "users",
+						Columns: []models.Column{{Name: "email", DataType: "varchar"}},
+						Indexes: []models.Index{
+							{
+								Name:    "users_email_idx",
+								Type:    "INDEX",
+								Columns: []string{"(lower(`email`))", "created_at"},
+								Method:  "BTREE",
+								KeyParts: []models.IndexKeyPart{
+									{Expression: "(lower(`email`))"},
+									{Column: "created_at", Descending: true},
+								},
+								IsValid: true,
+							},
+						},
+					},
+				},
+			},
+			expectContains: []string{
+				"| users_email_idx | INDEX | (lower(`email`)), created_at DESC | BTREE |  |  | YES |",
+			},
+		},
+		{
+			name: "exported snapshot",
+			schema: models.Schema{
+				Name:     "shop",
+				Snapshot: &models.Snapshot{ID: "00000003-0000001B-1", TakenAt: time.Date(2026, 3, 14, 9, 30, 0, 0, time.UTC)},
+				Tables: []models.Table{
+					{Schema: "public", Name: "users", Columns: []models.Column{{Name: "id", DataType: "integer"}}},
+				},
+			},
+			expectContains: []string{
+				"Snapshot: `00000003-0000001B-1` taken at 2026-03-14 09:30:00 UTC",
+			},
+		},
+		{
+			name: "gaps in this report",
+			schema: models.Schema{
+				Name: "shop",
+				Warnings: []models.Warning{
+					{Reason: models.WarningPermissionDenied, Message: "failed to get table statistics: pq: permission denied for table pg_stat_user_tables"},
+					{Reason: models.WarningTimeout, Message: "failed to get columns for view public.report: pq: canceling statement due to statement timeout"},
+				},
+			},
+			expectContains: []string{
+				"## Gaps in this report",
+				"| Reason | Detail |",
+				"| permission denied | failed to get table statistics: pq: permission denied for table pg_stat_user_tables |",
+				"| timeout | failed to get columns for view public.report: pq: canceling statement due to statement timeout |",
+				"No tables found in the database.",
+			},
+		},
+		{
+			name: "tables denied",
+			schema: models.Schema{
+				Name:     "shop",
+				Snapshot: &models.Snapshot{ID: "00000003-0000001B-1", TakenAt: time.Date(2026, 3, 14, 9, 30, 0, 0, time.UTC)},
+				Views: []models.View{
+					{Schema: "public", Name: "active_customers", Description: "Customers with an open contract"},
+				},
+				Warnings: []models.Warning{
+					{Reason: models.WarningPermissionDenied, Message: "failed to get tables: pq: permission denied for table pg_class"},
+				},
+			},
+			expectContains: []string{
+				"Snapshot: `00000003-0000001B-1` taken at 2026-03-14 09:30:00 UTC",
+				"## Gaps in this report",
+				"| permission denied | failed to get tables: pq: permission denied for table pg_class |",
+				"- [Views](#views)",
+				"| active_customers | public | Customers with an open contract |",
+				"## Tables\n\nNo tables found in the database.",
+			},
+		},
+		{
+			name: "snapshot without an id",
+			schema: models.Schema{
+				Name:     "shop",
+				Snapshot: &models.Snapshot{TakenAt: time.Date(2026, 3, 14, 9, 30, 0, 0, time.UTC)},
+				Tables: []models.Table{
+					{Schema: "shop", Name: "users", Columns: []models.Column{{Name: "id", DataType: "int"}}},
+				},
+			},
+			expectContains: []string{
+				"Snapshot taken at: 2026-03-14 09:30:00 UTC",
+			},
+		},
+		{
+			name: "sequence usage and ownership",
+			schema: models.Schema{
+				Name: "public",
+				Tables: []models.Table{
+					{Schema: "public", Name: "orders", Columns: []models.Column{{Name: "id", DataType: "integer"}}},
+				},
+				Sequences: []models.Sequence{
+					{Schema: "public", Name: "orders_id_seq", DataType: "integer", MinValue: 1, MaxValue: 2147483647, Increment: 1,
+						LastValue: int64Ptr(1825361100), PercentUsed: 85, OwnerTable: "public.orders", OwnerColumn: "id"},
+					{Schema: "public", Name: "events_id_seq", DataType: "bigint", MinValue: 1, MaxValue: 100, Increment: 1,
+						LastValue: int64Ptr(60), PercentUsed: 60, OwnerTable: "public.events", OwnerColumn: "id", IsIdentity: true},
+					{Schema: "public", Name: "unused_seq", DataType: "bigint", MinValue: 1, MaxValue: 100, Increment: 1},
+				},
+			},
+			expectContains: []string{
+				"> **Critical:** `public.orders_id_seq` (feeding `public.orders.id`) has used 85.0% of its range.",
+				"> **Warning:** `public.events_id_seq` (feeding `public.events.id`) has used 60.0% of its range.",
+				"| orders_id_seq | public | integer | 0 | 1 | 2147483647 | 1 | 1825361100 | 85.0% (critical) | public.orders.id |  |",
+				"| events_id_seq | public | bigint | 0 | 1 | 100 | 1 | 60 | 60.0% (warning) | public.events.id (identity) |  |",
+				"| unused_seq | public | bigint | 0 | 1 | 100 | 1 |  |  |  |  |",
+			},
+		},
+		{
+			name: "activity statistics and hottest tables",
+			schema: models.Schema{
+				Name: "public",
+				Tables: []models.Table{
+					{
+						Schema:  "public",
+						Name:    "settings",
+						Columns: []models.Column{{Name: "id", DataType: "bigint"}},
+						Stats:   &models.TableStats{IndexScans: 40},
+					},
+					{
+						Schema:  "public",
+						Name:    "events",
+						Columns: []models.Column{{Name: "id", DataType: "bigint"}},
+						Stats: &models.TableStats{
+							SeqScans:        3,
+							IndexScans:      1200,
+							TuplesInserted:  900,
+							TuplesUpdated:   50,
+							TuplesDeleted:   10,
+							LiveTuples:      890,
+							DeadTuples:      60,
+							LastAutovacuum:  timePtr(time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)),
+							HeapBlocksHit:   990,
+							HeapBlocksRead:  10,
+							IndexBlocksHit:  0,
+							IndexBlocksRead: 0,
+						},
+					},
+					{
+						Schema:  "public",
+						Name:    "archive",
+						Columns: []models.Column{{Name: "id", DataType: "bigint"}},
+						Stats:   &models.TableStats{},
+					},
+				},
+			},
+			expectContains: []string{
+				"### Hottest Tables",
+				"| public.events | 960 | 900 | 50 | 10 | 3 | 1200 | 60 | 99.0% |\n| public.settings | 0 | 0 | 0 | 0 | 0 | 40 | 0 | n/a |\n\n",
+				"### Activity",
+				"| Dead tuples | 60 |",
+				"| Last vacuum | never |",
+				"| Last autovacuum | 2024-03-01 12:30:00 |",
+				"| Heap cache hit ratio | 99.0% |",
+				"| Index cache hit ratio | n/a |",
+			},
+		},
 		{
 			name: "complete formatted report with TOC",
 			schema: models.Schema{
@@ -143,11 +1013,11 @@ func TestGenerateMarkdown(t *testing.T) {
 						},
 						ForeignKeys: []models.ForeignKey{
 							{
-								Name:             "fk_orders_user_id",
-								SourceTable:      "orders",
-								SourceColumn:     "user_id",
-								ReferencedTable:  "public.users",
-								ReferencedColumn: "id",
+								Name:              "fk_orders_user_id",
+								SourceTable:       "orders",
+								SourceColumns:     []string{"user_id"},
+								ReferencedTable:   "public.users",
+								ReferencedColumns: []string{"id"},
 							},
 						},
 						RowCount: 8500,
@@ -195,3 +1065,42 @@ func intPtr(i int) *int {
 func stringPtr(s string) *string {
 	return &s
 }
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+func int64Ptr(n int64) *int64 {
+	return &n
+}
+
+func TestMarkdownReporterSequenceThresholds(t *testing.T) {
+	schema := &models.Schema{
+		Tables: []models.Table{
+			{Schema: "public", Name: "orders", Columns: []models.Column{{Name: "id", DataType: "integer"}}},
+		},
+		Sequences: []models.Sequence{
+			{Schema: "public", Name: "orders_id_seq", DataType: "integer", MinValue: 1, MaxValue: 2147483647, Increment: 1,
+				LastValue: int64Ptr(1825361100), PercentUsed: 85, OwnerTable: "public.orders", OwnerColumn: "id"},
+			{Schema: "public", Name: "events_id_seq", DataType: "bigint", MinValue: 1, MaxValue: 100, Increment: 1,
+				LastValue: int64Ptr(60), PercentUsed: 60, OwnerTable: "public.events", OwnerColumn: "id", IsIdentity: true},
+			{Schema: "public", Name: "unused_seq", DataType: "bigint", MinValue: 1, MaxValue: 100, Increment: 1},
+		},
+	}
+
+	reporter := NewMarkdownReporter()
+	reporter.SetSequenceThresholds(70, 90)
+
+	output, err := reporter.Generate(schema)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(output, "> **Warning:** `public.orders_id_seq`") {
+		t.Errorf("expected orders_id_seq to be a warning at 85%% with a 90%% critical threshold.\nOutput:\n%s", output)
+	}
+
+	if strings.Contains(output, "`public.events_id_seq` (feeding") || strings.Contains(output, "60.0% (warning)") {
+		t.Errorf("expected events_id_seq to go unflagged below a 70%% warning threshold.\nOutput:\n%s", output)
+	}
+}
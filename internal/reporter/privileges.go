@@ -0,0 +1,122 @@
+package reporter
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/orchard9/pg-goer/pkg/models"
+)
+
+// privilegeCodes are the single-letter abbreviations psql uses in \dp,
+// listed in the order psql prints them.
+var privilegeCodes = []struct {
+	privilege string
+	code      string
+}{
+	{"INSERT", "a"},
+	{"SELECT", "r"},
+	{"UPDATE", "w"},
+	{"DELETE", "d"},
+	{"TRUNCATE", "D"},
+	{"REFERENCES", "x"},
+	{"TRIGGER", "t"},
+	{"MAINTAIN", "m"},
+	{"EXECUTE", "X"},
+	{"USAGE", "U"},
+	{"CREATE", "C"},
+	{"CONNECT", "c"},
+	{"TEMPORARY", "T"},
+}
+
+// privilegeMatrix arranges grants as one row per object and one column per
+// role.
+type privilegeMatrix struct {
+	Roles   []string
+	Objects []objectPrivileges
+}
+
+type objectPrivileges struct {
+	ObjectType string
+	Schema     string
+	Object     string
+	Column     string
+	Grants     map[string][]models.Grant
+}
+
+// buildPrivilegeMatrix groups grants by object, keeping the order in which
+// objects first appear, and collects the sorted set of roles.
+func buildPrivilegeMatrix(grants []models.Grant) privilegeMatrix {
+	var matrix privilegeMatrix
+
+	objectIndex := make(map[string]int)
+	seenRoles := make(map[string]bool)
+
+	for i := range grants {
+		grant := &grants[i]
+		key := grant.ObjectType + "\x00" + grant.Schema + "\x00" + grant.Object + "\x00" + grant.Column
+
+		idx, exists := objectIndex[key]
+		if !exists {
+			idx = len(matrix.Objects)
+			objectIndex[key] = idx
+
+			matrix.Objects = append(matrix.Objects, objectPrivileges{
+				ObjectType: grant.ObjectType,
+				Schema:     grant.Schema,
+				Object:     grant.Object,
+				Column:     grant.Column,
+				Grants:     make(map[string][]models.Grant),
+			})
+		}
+
+		matrix.Objects[idx].Grants[grant.Grantee] = append(matrix.Objects[idx].Grants[grant.Grantee], *grant)
+
+		if !seenRoles[grant.Grantee] {
+			seenRoles[grant.Grantee] = true
+
+			matrix.Roles = append(matrix.Roles, grant.Grantee)
+		}
+	}
+
+	sort.Strings(matrix.Roles)
+
+	return matrix
+}
+
+// formatPrivileges abbreviates a role's privileges on one object the way
+// psql does, e.g. "arwd", with "*" after privileges held WITH GRANT OPTION.
+// Privileges psql has no letter for are appended by name.
+func formatPrivileges(grants []models.Grant) string {
+	held := make(map[string]string, len(grants))
+	for i := range grants {
+		suffix := ""
+		if grants[i].IsGrantable {
+			suffix = "*"
+		}
+
+		held[grants[i].Privilege] = suffix
+	}
+
+	var codes strings.Builder
+
+	for _, pc := range privilegeCodes {
+		if suffix, exists := held[pc.privilege]; exists {
+			codes.WriteString(pc.code + suffix)
+			delete(held, pc.privilege)
+		}
+	}
+
+	parts := make([]string, 0, len(held)+1)
+	if codes.Len() > 0 {
+		parts = append(parts, codes.String())
+	}
+
+	var others []string
+	for privilege, suffix := range held {
+		others = append(others, privilege+suffix)
+	}
+
+	sort.Strings(others)
+
+	return strings.Join(append(parts, others...), ", ")
+}
@@ -0,0 +1,95 @@
+package reporter
+
+import (
+	"testing"
+
+	"github.com/orchard9/pg-goer/pkg/models"
+)
+
+func TestBuildPrivilegeMatrix(t *testing.T) {
+	grants := []models.Grant{
+		{ObjectType: "schema", Schema: "public", Object: "public", Grantee: "app", Privilege: "USAGE"},
+		{ObjectType: "table", Schema: "public", Object: "users", Grantee: "reporting", Privilege: "SELECT"},
+		{ObjectType: "table", Schema: "public", Object: "users", Grantee: "app", Privilege: "SELECT"},
+		{ObjectType: "table", Schema: "public", Object: "users", Grantee: "app", Privilege: "INSERT"},
+		{ObjectType: "column", Schema: "public", Object: "users", Column: "email", Grantee: "support", Privilege: "SELECT"},
+	}
+
+	matrix := buildPrivilegeMatrix(grants)
+
+	expectedRoles := []string{"app", "reporting", "support"}
+	if len(matrix.Roles) != len(expectedRoles) {
+		t.Fatalf("expected roles %v, got %v", expectedRoles, matrix.Roles)
+	}
+
+	for i, role := range expectedRoles {
+		if matrix.Roles[i] != role {
+			t.Errorf("expected role %d to be %s, got %s", i, role, matrix.Roles[i])
+		}
+	}
+
+	if len(matrix.Objects) != 3 {
+		t.Fatalf("expected 3 objects, got %d", len(matrix.Objects))
+	}
+
+	users := matrix.Objects[1]
+	if users.Object != "users" || users.Column != "" {
+		t.Errorf("expected second object to be the users table, got %+v", users)
+	}
+
+	if len(users.Grants["app"]) != 2 || len(users.Grants["reporting"]) != 1 {
+		t.Errorf("unexpected grants on users: %+v", users.Grants)
+	}
+
+	if matrix.Objects[2].Column != "email" {
+		t.Errorf("expected column grant last, got %+v", matrix.Objects[2])
+	}
+}
+
+func TestFormatPrivileges(t *testing.T) {
+	tests := []struct {
+		name     string
+		grants   []models.Grant
+		expected string
+	}{
+		{
+			name:     "no privileges",
+			expected: "",
+		},
+		{
+			name: "psql order regardless of input order",
+			grants: []models.Grant{
+				{Privilege: "DELETE"},
+				{Privilege: "SELECT"},
+				{Privilege: "UPDATE"},
+				{Privilege: "INSERT"},
+			},
+			expected: "arwd",
+		},
+		{
+			name: "grant option",
+			grants: []models.Grant{
+				{Privilege: "SELECT", IsGrantable: true},
+				{Privilege: "UPDATE"},
+			},
+			expected: "r*w",
+		},
+		{
+			name: "privileges without a psql letter",
+			grants: []models.Grant{
+				{Privilege: "SELECT"},
+				{Privilege: "SHOW VIEW"},
+				{Privilege: "ALTER"},
+			},
+			expected: "r, ALTER, SHOW VIEW",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatPrivileges(tt.grants); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
@@ -2,11 +2,15 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
+	"maps"
 	"os"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/orchard9/pg-goer/internal/analyzer"
@@ -15,12 +19,31 @@ import (
 )
 
 const (
-	defaultOutput    = "database-docs.md"
-	defaultFormat    = "markdown"
-	defaultTimeout   = 10 * time.Second
-	defaultMaxTables = 1000
+	defaultOutput       = "database-docs.md"
+	defaultFormat       = "markdown"
+	defaultTimeout      = 10 * time.Second
+	defaultMaxTables    = 1000
+	defaultCountTimeout = 30 * time.Second
+	defaultConcurrency  = 4
+
+	// Safe mode session limits.
+	defaultStatementTimeout = 30 * time.Second
+	defaultLockTimeout      = 5 * time.Second
+	defaultIdleTimeout      = 10 * time.Minute
 )
 
+// Exit codes. The flag package exits with 2 on invalid flags, so a report
+// with gaps gets 3.
+const (
+	exitSuccess = 0
+	exitFailure = 1
+	exitPartial = 3
+)
+
+// errIncomplete marks a report that was written with gaps: objects that could
+// not be read for lack of privileges or time.
+var errIncomplete = errors.New("the report is incomplete")
+
 var (
 	version = "dev"
 	commit  = "none"
@@ -36,6 +59,19 @@ func main() {
 		showHelp   bool
 		verbose    bool
 		versionCmd bool
+		expandPart bool
+		withSource bool
+		sysRoles   bool
+		exclRoles  string
+		rowCounts  string
+		countLimit time.Duration
+		seqWarn    float64
+		seqCrit    float64
+		workers    int
+		safe       bool
+		stmtLimit  time.Duration
+		lockLimit  time.Duration
+		idleLimit  time.Duration
 	)
 
 	flag.StringVar(&output, "output", defaultOutput, "Output file")
@@ -44,6 +80,19 @@ func main() {
 	flag.StringVar(&format, "f", defaultFormat, "Output format (shorthand)")
 	flag.StringVar(&schemas, "schemas", "", "Comma-separated list of schemas to document")
 	flag.StringVar(&dbType, "database-type", "", "Database type (postgresql or mariadb) - auto-detected if not specified")
+	flag.BoolVar(&expandPart, "expand-partitions", false, "Document each partition as its own table instead of collapsing it under its parent")
+	flag.BoolVar(&withSource, "include-function-source", false, "Include the source body of functions and procedures")
+	flag.BoolVar(&sysRoles, "include-system-roles", false, "Include built-in and system roles in the privilege matrix")
+	flag.StringVar(&exclRoles, "exclude-roles", "", "Comma-separated list of roles to leave out of the privilege matrix")
+	flag.StringVar(&rowCounts, "row-counts", string(models.RowCountEstimate), "Row count method (estimate, exact, sample or none)")
+	flag.DurationVar(&countLimit, "row-count-timeout", defaultCountTimeout, "Time limit for each exact or sampled row count")
+	flag.Float64Var(&seqWarn, "sequence-warning", reporter.DefaultSequenceWarning, "Flag sequences that have used this percentage of their range")
+	flag.Float64Var(&seqCrit, "sequence-critical", reporter.DefaultSequenceCritical, "Flag sequences that have used this percentage of their range as critical")
+	flag.IntVar(&workers, "concurrency", defaultConcurrency, "Maximum number of catalog queries to run at once")
+	flag.BoolVar(&safe, "safe", false, "Production-safe mode: set statement, lock and idle timeouts, application_name and read-only on every session")
+	flag.DurationVar(&stmtLimit, "statement-timeout", defaultStatementTimeout, "Time limit for each query in safe mode")
+	flag.DurationVar(&lockLimit, "lock-timeout", defaultLockTimeout, "Time limit for each lock wait in safe mode")
+	flag.DurationVar(&idleLimit, "idle-timeout", defaultIdleTimeout, "Time limit for an idle transaction in safe mode (PostgreSQL only)")
 	flag.BoolVar(&showHelp, "help", false, "Show help")
 	flag.BoolVar(&showHelp, "h", false, "Show help (shorthand)")
 	flag.BoolVar(&verbose, "verbose", false, "Enable verbose output")
@@ -73,12 +122,12 @@ func main() {
 
 	if versionCmd {
 		fmt.Printf("pg-goer version %s (commit: %s, built: %s)\n", version, commit, date)
-		os.Exit(0)
+		os.Exit(exitSuccess)
 	}
 
 	if showHelp {
 		flag.Usage()
-		os.Exit(0)
+		os.Exit(exitSuccess)
 	}
 
 	connectionString := ""
@@ -89,15 +138,7 @@ func main() {
 	} else {
 		fmt.Fprintf(os.Stderr, "Error: connection string required\n\n")
 		flag.Usage()
-		os.Exit(1)
-	}
-
-	var schemaList []string
-	if schemas != "" {
-		schemaList = strings.Split(schemas, ",")
-		for i := range schemaList {
-			schemaList[i] = strings.TrimSpace(schemaList[i])
-		}
+		os.Exit(exitFailure)
 	}
 
 	if verbose {
@@ -106,95 +147,565 @@ func main() {
 		log.SetOutput(os.Stderr)
 	}
 
-	if err := run(connectionString, output, format, schemaList, dbType); err != nil {
+	opts := runOptions{
+		output:             output,
+		format:             format,
+		schemas:            splitList(schemas),
+		dbType:             dbType,
+		expandPartitions:   expandPart,
+		includeSource:      withSource,
+		includeSystemRoles: sysRoles,
+		excludeRoles:       splitList(exclRoles),
+		rowCountMethod:     models.RowCountMethod(rowCounts),
+		rowCountTimeout:    countLimit,
+		sequenceWarning:    seqWarn,
+		sequenceCritical:   seqCrit,
+		concurrency:        workers,
+	}
+
+	if safe {
+		opts.safeMode = &analyzer.SafeMode{StatementTimeout: stmtLimit, LockTimeout: lockLimit, IdleTimeout: idleLimit}
+	}
+
+	err := run(connectionString, opts)
+
+	switch {
+	case errors.Is(err, errIncomplete):
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	case err != nil:
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
 	}
+
+	os.Exit(exitCode(err))
+}
+
+// exitCode distinguishes a complete report, a report with gaps and a failed
+// run.
+func exitCode(err error) int {
+	switch {
+	case err == nil:
+		return exitSuccess
+	case errors.Is(err, errIncomplete):
+		return exitPartial
+	default:
+		return exitFailure
+	}
+}
+
+// runOptions holds the command-line settings that shape extraction and output.
+type runOptions struct {
+	output             string
+	format             string
+	schemas            []string
+	dbType             string
+	expandPartitions   bool
+	includeSource      bool
+	includeSystemRoles bool
+	excludeRoles       []string
+	rowCountMethod     models.RowCountMethod
+	rowCountTimeout    time.Duration
+	sequenceWarning    float64
+	sequenceCritical   float64
+	concurrency        int
+	safeMode           *analyzer.SafeMode // nil unless --safe is given
+}
+
+// splitList parses a comma-separated flag value.
+func splitList(value string) []string {
+	if value == "" {
+		return nil
+	}
+
+	items := strings.Split(value, ",")
+	for i := range items {
+		items[i] = strings.TrimSpace(items[i])
+	}
+
+	return items
 }
 
-func run(connectionString, output, format string, schemas []string, dbTypeFlag string) error {
+func run(connectionString string, opts runOptions) error {
 	// Validate format
-	if format != "markdown" && format != "json" {
-		return fmt.Errorf("invalid format '%s': must be 'markdown' or 'json'", format)
+	if opts.format != "markdown" && opts.format != "json" {
+		return fmt.Errorf("invalid format '%s': must be 'markdown' or 'json'", opts.format)
+	}
+
+	switch opts.rowCountMethod {
+	case models.RowCountEstimate, models.RowCountExact, models.RowCountSample, models.RowCountNone:
+	default:
+		return fmt.Errorf("invalid row count method '%s': must be 'estimate', 'exact', 'sample' or 'none'", opts.rowCountMethod)
+	}
+
+	if opts.sequenceWarning <= 0 || opts.sequenceWarning > opts.sequenceCritical || opts.sequenceCritical > 100 {
+		return fmt.Errorf("invalid sequence thresholds %g/%g: must satisfy 0 < warning <= critical <= 100",
+			opts.sequenceWarning, opts.sequenceCritical)
+	}
+
+	if opts.concurrency < 1 || opts.concurrency > analyzer.MaxOpenConnections {
+		return fmt.Errorf("invalid concurrency %d: must be between 1 and %d", opts.concurrency, analyzer.MaxOpenConnections)
+	}
+
+	if mode := opts.safeMode; mode != nil && (mode.StatementTimeout < 0 || mode.LockTimeout < 0 || mode.IdleTimeout < 0) {
+		return fmt.Errorf("invalid safe mode timeouts: must not be negative")
 	}
 
 	ctx := context.Background()
 
-	conn, databaseAnalyzer, err := connectToDatabase(ctx, connectionString, dbTypeFlag)
+	conn, err := connectToDatabase(ctx, connectionString, opts.dbType, opts.safeMode)
 	if err != nil {
 		return err
 	}
 
 	defer conn.Close()
 
-	tables, err := fetchAllTableData(ctx, databaseAnalyzer, schemas)
+	conns, snapshot, err := beginSnapshot(ctx, conn, opts.concurrency)
 	if err != nil {
 		return err
 	}
 
-	extensions, err := fetchExtensions(ctx, databaseAnalyzer)
+	defer closeAll(conns)
+
+	workers, err := newWorkers(conns)
 	if err != nil {
 		return err
 	}
 
-	views, err := fetchViews(ctx, databaseAnalyzer, schemas)
+	schema, err := fetchSchema(ctx, workers, opts)
 	if err != nil {
 		return err
 	}
 
-	sequences, err := fetchSequences(ctx, databaseAnalyzer, schemas)
+	schema.Snapshot = snapshot
+
+	if schema.Name == "" {
+		// The overview query was denied or timed out; name the report
+		// after the database the connection string names instead.
+		if schema.Name, err = analyzer.DatabaseName(conn.DatabaseType(), connectionString); err != nil {
+			return err
+		}
+	}
+
+	for _, warning := range schema.Warnings {
+		log.Printf("Warning: %s\n", warning.Message)
+	}
+
+	if err := generateAndWriteDocumentation(schema, opts); err != nil {
+		return err
+	}
+
+	if len(schema.Warnings) > 0 {
+		return fmt.Errorf("%w: %d parts of the schema could not be read", errIncomplete, len(schema.Warnings))
+	}
+
+	return nil
+}
+
+// fetchSchema reads every documented object kind from the database. Queries
+// run on the workers concurrently in two rounds: first the object
+// collections, then the per-object metadata that depends on them. Every task
+// writes to its own destination, so the assembled schema does not depend on
+// the order in which the queries finish. Tasks that time out or lack
+// privileges leave their part of the schema empty and are listed in its
+// warnings.
+func fetchSchema(ctx context.Context, workers []worker, opts runOptions) (*models.Schema, error) {
+	var (
+		schema   = &models.Schema{}
+		tables   []models.Table
+		warnings warningLog
+	)
+
+	err := extract(ctx, workers, &warnings, []func(context.Context, analyzer.DatabaseAnalyzer) error{
+		func(ctx context.Context, databaseAnalyzer analyzer.DatabaseAnalyzer) (err error) {
+			tables, err = fetchTables(ctx, databaseAnalyzer, opts.schemas)
+			return err
+		},
+		func(ctx context.Context, databaseAnalyzer analyzer.DatabaseAnalyzer) (err error) {
+			schema.Extensions, err = fetchExtensions(ctx, databaseAnalyzer)
+			return err
+		},
+		func(ctx context.Context, databaseAnalyzer analyzer.DatabaseAnalyzer) (err error) {
+			schema.Views, err = fetchViews(ctx, databaseAnalyzer, opts.schemas)
+			return err
+		},
+		func(ctx context.Context, databaseAnalyzer analyzer.DatabaseAnalyzer) (err error) {
+			schema.MaterializedViews, err = fetchMaterializedViews(ctx, databaseAnalyzer, opts.schemas)
+			return err
+		},
+		func(ctx context.Context, databaseAnalyzer analyzer.DatabaseAnalyzer) (err error) {
+			schema.Sequences, err = fetchSequences(ctx, databaseAnalyzer, opts.schemas)
+			return err
+		},
+		func(ctx context.Context, databaseAnalyzer analyzer.DatabaseAnalyzer) (err error) {
+			schema.Types, err = fetchTypes(ctx, databaseAnalyzer, opts.schemas)
+			return err
+		},
+		func(ctx context.Context, databaseAnalyzer analyzer.DatabaseAnalyzer) (err error) {
+			schema.Routines, err = fetchRoutines(ctx, databaseAnalyzer, opts.schemas, opts.includeSource)
+			return err
+		},
+		func(ctx context.Context, databaseAnalyzer analyzer.DatabaseAnalyzer) (err error) {
+			schema.Grants, err = fetchGrants(ctx, databaseAnalyzer, opts)
+			return err
+		},
+		func(ctx context.Context, databaseAnalyzer analyzer.DatabaseAnalyzer) (err error) {
+			schema.SizeBytes, err = fetchDatabaseSize(ctx, databaseAnalyzer)
+			return err
+		},
+		func(ctx context.Context, databaseAnalyzer analyzer.DatabaseAnalyzer) (err error) {
+			schema.Database, err = fetchDatabaseInfo(ctx, databaseAnalyzer, opts.schemas)
+			return err
+		},
+		func(ctx context.Context, databaseAnalyzer analyzer.DatabaseAnalyzer) error {
+			return fetchDatabaseLevelObjects(ctx, databaseAnalyzer, schema, opts.schemas)
+		},
+		func(ctx context.Context, databaseAnalyzer analyzer.DatabaseAnalyzer) error {
+			return fetchForeignData(ctx, databaseAnalyzer, schema, opts.schemas)
+		},
+	})
 	if err != nil {
+		return nil, err
+	}
+
+	tables = organizePartitions(tables, opts.expandPartitions)
+	sumPartitionSizes(tables)
+
+	metadata, tasks := tableMetadataTasks(tables, opts)
+
+	for i := range schema.Views {
+		view := &schema.Views[i]
+		tasks = append(tasks, func(ctx context.Context, databaseAnalyzer analyzer.DatabaseAnalyzer) error {
+			return enrichView(ctx, databaseAnalyzer, view)
+		})
+	}
+
+	for i := range schema.MaterializedViews {
+		view := &schema.MaterializedViews[i]
+		tasks = append(tasks, func(ctx context.Context, databaseAnalyzer analyzer.DatabaseAnalyzer) error {
+			return enrichMaterializedView(ctx, databaseAnalyzer, view)
+		})
+	}
+
+	for i := range schema.ForeignTables {
+		table := &schema.ForeignTables[i]
+		tasks = append(tasks, func(ctx context.Context, databaseAnalyzer analyzer.DatabaseAnalyzer) error {
+			return fetchForeignTableColumns(ctx, databaseAnalyzer, table)
+		})
+	}
+
+	if err := extract(ctx, workers, &warnings, tasks); err != nil {
+		return nil, err
+	}
+
+	metadata.apply(tables)
+	applyPublications(tables, schema.Publications)
+
+	if schema.Database != nil {
+		schema.Name = schema.Database.Name
+	}
+
+	schema.Tables = tables
+	schema.Warnings = warnings.sorted()
+
+	return schema, nil
+}
+
+// worker is one snapshot transaction and the analyzer that queries through
+// it.
+type worker struct {
+	conn     *analyzer.Connection
+	analyzer analyzer.DatabaseAnalyzer
+}
+
+// warningLog collects warnings from concurrently running tasks.
+type warningLog struct {
+	mu       sync.Mutex
+	warnings []models.Warning
+}
+
+func (l *warningLog) add(warning models.Warning) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.warnings = append(l.warnings, warning)
+}
+
+// sorted returns the warnings in a stable order, independent of the order in
+// which the tasks finished.
+func (l *warningLog) sorted() []models.Warning {
+	sort.Slice(l.warnings, func(i, j int) bool { return l.warnings[i].Message < l.warnings[j].Message })
+
+	return l.warnings
+}
+
+// extract runs tasks on the workers, each under a savepoint of its worker's
+// snapshot transaction. A task stopped by a server-side timeout or by missing
+// privileges is rolled back to its savepoint and recorded as a warning
+// instead of failing the run, leaving its part of the schema empty. A batch
+// that times out is retried part by part on the same worker, so that only
+// the slow parts are recorded.
+func extract(ctx context.Context, workers []worker, warnings *warningLog, tasks []func(context.Context, analyzer.DatabaseAnalyzer) error) error {
+	guarded := make([]func(context.Context, worker) error, len(tasks))
+
+	for i, task := range tasks {
+		guarded[i] = func(ctx context.Context, w worker) error {
+			return extractGuarded(ctx, w, warnings, task)
+		}
+	}
+
+	return runTasks(ctx, workers, guarded)
+}
+
+func extractGuarded(ctx context.Context, w worker, warnings *warningLog, task func(context.Context, analyzer.DatabaseAnalyzer) error) error {
+	err := w.conn.Guard(ctx, func() error { return task(ctx, w.analyzer) })
+	if err == nil || ctx.Err() != nil {
 		return err
 	}
 
-	schema := models.Schema{
-		Name:       "Database Documentation",
-		Tables:     tables,
-		Views:      views,
-		Sequences:  sequences,
-		Extensions: extensions,
+	var split *batchTimeoutError
+
+	switch {
+	case errors.As(err, &split):
+		log.Printf("Warning: %v; retrying %d tables one at a time\n", split.err, len(split.parts))
+
+		for _, part := range split.parts {
+			if err := extractGuarded(ctx, w, warnings, part); err != nil {
+				return err
+			}
+		}
+	case analyzer.IsTimeout(err):
+		warnings.add(models.Warning{Reason: models.WarningTimeout, Message: err.Error()})
+	case analyzer.IsPermissionDenied(err):
+		warnings.add(models.Warning{Reason: models.WarningPermissionDenied, Message: err.Error()})
+	default:
+		return err
+	}
+
+	return nil
+}
+
+// batchTimeoutError reports that a query over a batch of tables timed out,
+// along with one task per table to retry it with.
+type batchTimeoutError struct {
+	err   error
+	parts []func(context.Context, analyzer.DatabaseAnalyzer) error
+}
+
+func (e *batchTimeoutError) Error() string { return e.err.Error() }
+
+func (e *batchTimeoutError) Unwrap() error { return e.err }
+
+// batched returns a task that runs fetch for all tables at once. If that
+// times out, the task fails with a batchTimeoutError holding one task per
+// table, whose errors name the table.
+func batched(what string, tables []models.Table, fetch func(context.Context, analyzer.DatabaseAnalyzer, []models.Table) error) func(context.Context, analyzer.DatabaseAnalyzer) error {
+	return func(ctx context.Context, databaseAnalyzer analyzer.DatabaseAnalyzer) error {
+		log.Printf("Fetching %s for %d tables...\n", what, len(tables))
+
+		err := fetch(ctx, databaseAnalyzer, tables)
+		if err == nil {
+			return nil
+		}
+
+		err = fmt.Errorf("failed to get %s: %w", what, err)
+		if len(tables) < 2 || !analyzer.IsTimeout(err) {
+			return err
+		}
+
+		split := &batchTimeoutError{err: err, parts: make([]func(context.Context, analyzer.DatabaseAnalyzer) error, len(tables))}
+
+		for i := range tables {
+			table := tables[i : i+1]
+			split.parts[i] = func(ctx context.Context, databaseAnalyzer analyzer.DatabaseAnalyzer) error {
+				if err := fetch(ctx, databaseAnalyzer, table); err != nil {
+					return fmt.Errorf("failed to get %s for table %s.%s: %w", what, table[0].Schema, table[0].Name, err)
+				}
+
+				return nil
+			}
+		}
+
+		return split
 	}
+}
+
+// fetchInto adapts a bulk metadata query to batched, merging each call's
+// results into dst.
+func fetchInto[V any](dst *map[string]V, get func(analyzer.DatabaseAnalyzer, context.Context, []models.Table) (map[string]V, error)) func(context.Context, analyzer.DatabaseAnalyzer, []models.Table) error {
+	return func(ctx context.Context, databaseAnalyzer analyzer.DatabaseAnalyzer, tables []models.Table) error {
+		results, err := get(databaseAnalyzer, ctx, tables)
+		if err != nil {
+			return err
+		}
 
-	return generateAndWriteDocumentation(&schema, format, output)
+		if *dst == nil {
+			*dst = make(map[string]V, len(results))
+		}
+
+		maps.Copy(*dst, results)
+
+		return nil
+	}
 }
 
-func connectToDatabase(ctx context.Context, connectionString string, dbTypeFlag string) (*analyzer.Connection, analyzer.DatabaseAnalyzer, error) {
+// runTasks runs tasks concurrently, handing each one a worker of its own;
+// at most len(workers) run at a time. The first task to fail cancels the
+// context handed to the others, and its error is returned once every started
+// task has finished.
+func runTasks[W any](ctx context.Context, workers []W, tasks []func(context.Context, W) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+
+	idle := make(chan W, len(workers))
+	for _, worker := range workers {
+		idle <- worker
+	}
+
+	for _, task := range tasks {
+		var worker W
+
+		select {
+		case worker = <-idle:
+		case <-ctx.Done():
+		}
+
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+
+		go func() {
+			defer func() {
+				idle <- worker
+				wg.Done()
+			}()
+
+			if err := task(ctx, worker); err != nil {
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+
+	return ctx.Err()
+}
+
+// beginSnapshot opens the read-only REPEATABLE READ transactions the
+// extraction runs in, one per worker. The first exports its snapshot and the
+// rest import it, so every query sees the same data however the work is
+// spread. MariaDB cannot share a snapshot between connections, so it gets a
+// single worker.
+func beginSnapshot(ctx context.Context, conn *analyzer.Connection, concurrency int) ([]*analyzer.Connection, *models.Snapshot, error) {
+	log.Println("Starting snapshot transaction...")
+
+	leader, snapshot, err := conn.BeginSnapshot(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	workers := []*analyzer.Connection{leader}
+
+	if conn.DatabaseType() != analyzer.PostgreSQL {
+		if concurrency > 1 {
+			log.Printf("%s cannot share a snapshot between connections; extracting on a single connection\n", conn.DatabaseType())
+		}
+
+		return workers, snapshot, nil
+	}
+
+	for len(workers) < concurrency {
+		worker, err := conn.JoinSnapshot(ctx, snapshot.ID)
+		if err != nil {
+			closeAll(workers)
+			return nil, nil, err
+		}
+
+		workers = append(workers, worker)
+	}
+
+	return workers, snapshot, nil
+}
+
+// closeAll ends the snapshot transactions, the exporting one last so the
+// snapshot stays valid while the others use it.
+func closeAll(workers []*analyzer.Connection) {
+	for i := len(workers) - 1; i >= 0; i-- {
+		_ = workers[i].Close()
+	}
+}
+
+// newWorkers pairs each snapshot connection with an analyzer.
+func newWorkers(conns []*analyzer.Connection) ([]worker, error) {
+	workers := make([]worker, len(conns))
+
+	for i, conn := range conns {
+		databaseAnalyzer, err := analyzer.NewDatabaseAnalyzer(conn.DatabaseType(), conn)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create database analyzer: %w", err)
+		}
+
+		workers[i] = worker{conn: conn, analyzer: databaseAnalyzer}
+	}
+
+	return workers, nil
+}
+
+func connectToDatabase(ctx context.Context, connectionString string, dbTypeFlag string, safeMode *analyzer.SafeMode) (*analyzer.Connection, error) {
 	log.Println("Connecting to database...")
 
-	var conn *analyzer.Connection
+	var dbType analyzer.DatabaseType
 	var err error
 
 	if dbTypeFlag != "" {
 		// Use explicitly specified database type
-		var dbType analyzer.DatabaseType
 		switch strings.ToLower(dbTypeFlag) {
 		case "postgresql", "postgres":
 			dbType = analyzer.PostgreSQL
 		case "mariadb", "mysql":
 			dbType = analyzer.MariaDB
 		default:
-			return nil, nil, fmt.Errorf("unsupported database type: %s", dbTypeFlag)
+			return nil, fmt.Errorf("unsupported database type: %s", dbTypeFlag)
 		}
-		conn, err = analyzer.ConnectWithType(ctx, connectionString, dbType)
 	} else {
 		// Auto-detect database type
-		conn, err = analyzer.Connect(ctx, connectionString)
+		dbType, err = analyzer.DetectDatabaseType(connectionString)
+		if err != nil {
+			return nil, fmt.Errorf("failed to detect database type: %w", err)
+		}
 	}
 
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to connect to database: %w", err)
+	var conn *analyzer.Connection
+
+	if safeMode != nil {
+		log.Printf("Safe mode: statement timeout %s, lock timeout %s\n", safeMode.StatementTimeout, safeMode.LockTimeout)
+
+		conn, err = analyzer.ConnectSafe(ctx, connectionString, dbType, *safeMode)
+	} else {
+		conn, err = analyzer.ConnectWithType(ctx, connectionString, dbType)
 	}
 
-	databaseAnalyzer, err := analyzer.NewDatabaseAnalyzer(conn.DatabaseType(), conn)
 	if err != nil {
-		conn.Close()
-		return nil, nil, fmt.Errorf("failed to create database analyzer: %w", err)
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
-	return conn, databaseAnalyzer, nil
+	return conn, nil
 }
 
-func fetchAllTableData(ctx context.Context, databaseAnalyzer analyzer.DatabaseAnalyzer, schemas []string) ([]models.Table, error) {
+func fetchTables(ctx context.Context, databaseAnalyzer analyzer.DatabaseAnalyzer, schemas []string) ([]models.Table, error) {
 	log.Println("Fetching tables...")
 
 	tables, err := databaseAnalyzer.GetTables(ctx, schemas)
@@ -204,14 +715,6 @@ func fetchAllTableData(ctx context.Context, databaseAnalyzer analyzer.DatabaseAn
 
 	log.Printf("Found %d tables\n", len(tables))
 
-	if err := enrichTablesWithMetadata(ctx, databaseAnalyzer, tables); err != nil {
-		return nil, err
-	}
-
-	if err := addRowCounts(ctx, databaseAnalyzer, tables); err != nil {
-		return nil, err
-	}
-
 	return tables, nil
 }
 
@@ -237,6 +740,71 @@ func fetchViews(ctx context.Context, databaseAnalyzer analyzer.DatabaseAnalyzer,
 	return views, nil
 }
 
+func enrichView(ctx context.Context, databaseAnalyzer analyzer.DatabaseAnalyzer, view *models.View) error {
+	log.Printf("Fetching columns, dependencies and triggers for view %s.%s...\n", view.Schema, view.Name)
+
+	columns, err := databaseAnalyzer.GetViewColumns(ctx, view)
+	if err != nil {
+		return fmt.Errorf("failed to get columns for view %s.%s: %w", view.Schema, view.Name, err)
+	}
+
+	view.Columns = columns
+
+	dependencies, err := databaseAnalyzer.GetViewDependencies(ctx, view)
+	if err != nil {
+		return fmt.Errorf("failed to get dependencies for view %s.%s: %w", view.Schema, view.Name, err)
+	}
+
+	view.Dependencies = dependencies
+
+	triggers, err := databaseAnalyzer.GetViewTriggers(ctx, view)
+	if err != nil {
+		return fmt.Errorf("failed to get triggers for view %s.%s: %w", view.Schema, view.Name, err)
+	}
+
+	view.Triggers = triggers
+
+	return nil
+}
+
+func fetchMaterializedViews(ctx context.Context, databaseAnalyzer analyzer.DatabaseAnalyzer, schemas []string) ([]models.MaterializedView, error) {
+	log.Println("Fetching materialized views...")
+
+	views, err := databaseAnalyzer.GetMaterializedViews(ctx, schemas)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get materialized views: %w", err)
+	}
+
+	return views, nil
+}
+
+func enrichMaterializedView(ctx context.Context, databaseAnalyzer analyzer.DatabaseAnalyzer, view *models.MaterializedView) error {
+	log.Printf("Fetching metadata for materialized view %s.%s...\n", view.Schema, view.Name)
+
+	columns, err := databaseAnalyzer.GetMaterializedViewColumns(ctx, view)
+	if err != nil {
+		return fmt.Errorf("failed to get columns for materialized view %s.%s: %w", view.Schema, view.Name, err)
+	}
+
+	view.Columns = columns
+
+	indexes, err := databaseAnalyzer.GetMaterializedViewIndexes(ctx, view)
+	if err != nil {
+		return fmt.Errorf("failed to get indexes for materialized view %s.%s: %w", view.Schema, view.Name, err)
+	}
+
+	view.Indexes = indexes
+
+	dependencies, err := databaseAnalyzer.GetMaterializedViewDependencies(ctx, view)
+	if err != nil {
+		return fmt.Errorf("failed to get dependencies for materialized view %s.%s: %w", view.Schema, view.Name, err)
+	}
+
+	view.Dependencies = dependencies
+
+	return nil
+}
+
 func fetchSequences(ctx context.Context, databaseAnalyzer analyzer.DatabaseAnalyzer, schemas []string) ([]models.Sequence, error) {
 	log.Println("Fetching sequences...")
 
@@ -248,124 +816,425 @@ func fetchSequences(ctx context.Context, databaseAnalyzer analyzer.DatabaseAnaly
 	return sequences, nil
 }
 
-func enrichTablesWithMetadata(ctx context.Context, databaseAnalyzer analyzer.DatabaseAnalyzer, tables []models.Table) error {
-	for i := range tables {
-		if err := fetchTableColumns(ctx, databaseAnalyzer, &tables[i]); err != nil {
-			return err
-		}
+func fetchRoutines(ctx context.Context, databaseAnalyzer analyzer.DatabaseAnalyzer, schemas []string, includeSource bool) ([]models.Routine, error) {
+	log.Println("Fetching functions and procedures...")
 
-		if err := fetchTableForeignKeys(ctx, databaseAnalyzer, &tables[i]); err != nil {
-			return err
-		}
+	routines, err := databaseAnalyzer.GetRoutines(ctx, schemas, includeSource)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get routines: %w", err)
+	}
 
-		if err := fetchTableIndexes(ctx, databaseAnalyzer, &tables[i]); err != nil {
-			return err
+	return routines, nil
+}
+
+func fetchGrants(ctx context.Context, databaseAnalyzer analyzer.DatabaseAnalyzer, opts runOptions) ([]models.Grant, error) {
+	log.Println("Fetching privileges...")
+
+	grants, err := databaseAnalyzer.GetGrants(ctx, opts.schemas)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get grants: %w", err)
+	}
+
+	return filterGrants(grants, opts.includeSystemRoles, opts.excludeRoles), nil
+}
+
+// filterGrants drops grants held by system roles unless they were asked for,
+// and grants held by any explicitly excluded role.
+func filterGrants(grants []models.Grant, includeSystemRoles bool, excludeRoles []string) []models.Grant {
+	excluded := make(map[string]bool, len(excludeRoles))
+	for _, role := range excludeRoles {
+		excluded[role] = true
+	}
+
+	var filtered []models.Grant
+
+	for i := range grants {
+		if grants[i].IsSystemRole && !includeSystemRoles {
+			continue
 		}
 
-		if err := fetchTableTriggers(ctx, databaseAnalyzer, &tables[i]); err != nil {
-			return err
+		if excluded[grants[i].Grantee] {
+			continue
 		}
+
+		filtered = append(filtered, grants[i])
 	}
 
-	return nil
+	return filtered
 }
 
-func fetchTableColumns(ctx context.Context, databaseAnalyzer analyzer.DatabaseAnalyzer, table *models.Table) error {
-	log.Printf("Fetching columns for %s.%s...\n", table.Schema, table.Name)
+func fetchTypes(ctx context.Context, databaseAnalyzer analyzer.DatabaseAnalyzer, schemas []string) ([]models.Type, error) {
+	log.Println("Fetching user-defined types...")
 
-	columns, err := databaseAnalyzer.GetColumns(ctx, table)
+	types, err := databaseAnalyzer.GetTypes(ctx, schemas)
 	if err != nil {
-		return fmt.Errorf("failed to get columns for %s.%s: %w", table.Schema, table.Name, err)
+		return nil, fmt.Errorf("failed to get types: %w", err)
 	}
 
-	table.Columns = columns
+	return types, nil
+}
 
-	return nil
+func fetchDatabaseSize(ctx context.Context, databaseAnalyzer analyzer.DatabaseAnalyzer) (int64, error) {
+	size, err := databaseAnalyzer.GetDatabaseSize(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get database size: %w", err)
+	}
+
+	return size, nil
 }
 
-func fetchTableForeignKeys(ctx context.Context, databaseAnalyzer analyzer.DatabaseAnalyzer, table *models.Table) error {
-	log.Printf("Fetching foreign keys for %s.%s...\n", table.Schema, table.Name)
+func fetchDatabaseInfo(ctx context.Context, databaseAnalyzer analyzer.DatabaseAnalyzer, schemas []string) (*models.DatabaseInfo, error) {
+	log.Println("Fetching database overview...")
+
+	info, err := databaseAnalyzer.GetDatabaseInfo(ctx, schemas)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database overview: %w", err)
+	}
+
+	return info, nil
+}
+
+// fetchDatabaseLevelObjects fills in event triggers, rules and publications.
+func fetchDatabaseLevelObjects(ctx context.Context, databaseAnalyzer analyzer.DatabaseAnalyzer, schema *models.Schema, schemas []string) error {
+	log.Println("Fetching event triggers, rules and publications...")
+
+	eventTriggers, err := databaseAnalyzer.GetEventTriggers(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get event triggers: %w", err)
+	}
+
+	rules, err := databaseAnalyzer.GetRules(ctx, schemas)
+	if err != nil {
+		return fmt.Errorf("failed to get rules: %w", err)
+	}
 
-	foreignKeys, err := databaseAnalyzer.GetForeignKeys(ctx, table)
+	publications, err := databaseAnalyzer.GetPublications(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to get foreign keys for %s.%s: %w", table.Schema, table.Name, err)
+		return fmt.Errorf("failed to get publications: %w", err)
 	}
 
-	table.ForeignKeys = foreignKeys
+	schema.EventTriggers = eventTriggers
+	schema.Rules = rules
+	schema.Publications = publications
 
 	return nil
 }
 
-func fetchTableIndexes(ctx context.Context, databaseAnalyzer analyzer.DatabaseAnalyzer, table *models.Table) error {
-	log.Printf("Fetching indexes for %s.%s...\n", table.Schema, table.Name)
+// fetchForeignData fills in foreign data wrappers, foreign servers with their
+// user mappings, and foreign tables. Foreign table columns are fetched later by
+// fetchForeignTableColumns.
+func fetchForeignData(ctx context.Context, databaseAnalyzer analyzer.DatabaseAnalyzer, schema *models.Schema, schemas []string) error {
+	log.Println("Fetching foreign data wrappers, servers and tables...")
+
+	wrappers, err := databaseAnalyzer.GetForeignDataWrappers(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get foreign data wrappers: %w", err)
+	}
+
+	servers, err := databaseAnalyzer.GetForeignServers(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get foreign servers: %w", err)
+	}
 
-	indexes, err := databaseAnalyzer.GetIndexes(ctx, table)
+	foreignTables, err := databaseAnalyzer.GetForeignTables(ctx, schemas)
 	if err != nil {
-		return fmt.Errorf("failed to get indexes for %s.%s: %w", table.Schema, table.Name, err)
+		return fmt.Errorf("failed to get foreign tables: %w", err)
 	}
 
-	table.Indexes = indexes
+	schema.ForeignDataWrappers = wrappers
+	schema.ForeignServers = servers
+	schema.ForeignTables = foreignTables
 
 	return nil
 }
 
-func fetchTableTriggers(ctx context.Context, databaseAnalyzer analyzer.DatabaseAnalyzer, table *models.Table) error {
-	log.Printf("Fetching triggers for %s.%s...\n", table.Schema, table.Name)
-
-	triggers, err := databaseAnalyzer.GetTriggers(ctx, table)
+func fetchForeignTableColumns(ctx context.Context, databaseAnalyzer analyzer.DatabaseAnalyzer, table *models.ForeignTable) error {
+	columns, err := databaseAnalyzer.GetForeignTableColumns(ctx, table)
 	if err != nil {
-		return fmt.Errorf("failed to get triggers for %s.%s: %w", table.Schema, table.Name, err)
+		return fmt.Errorf("failed to get columns for foreign table %s.%s: %w", table.Schema, table.Name, err)
 	}
 
-	table.Triggers = triggers
+	table.Columns = columns
 
 	return nil
 }
 
-func addRowCounts(ctx context.Context, databaseAnalyzer analyzer.DatabaseAnalyzer, tables []models.Table) error {
-	log.Println("Fetching table row counts...")
+// applyPublications lists on each table, and on its nested partitions, the
+// publications that include it.
+func applyPublications(tables []models.Table, publications []models.Publication) {
+	if len(publications) == 0 {
+		return
+	}
+
+	members := make(map[string][]string)
 
-	rowCounts, err := databaseAnalyzer.GetTableRowCounts(ctx, tables)
-	if err != nil {
-		return fmt.Errorf("failed to get table row counts: %w", err)
+	for i := range publications {
+		for _, table := range publications[i].Tables {
+			members[table] = append(members[table], publications[i].Name)
+		}
+	}
+
+	var apply func(tables []models.Table)
+
+	apply = func(tables []models.Table) {
+		for i := range tables {
+			tables[i].Publications = members[tables[i].Schema+"."+tables[i].Name]
+
+			apply(tables[i].Partitions)
+		}
+	}
+
+	apply(tables)
+}
+
+// tableMetadata collects the per-table metadata fetched for all tables at once,
+// keyed by "schema.table", until it is applied to the tables.
+type tableMetadata struct {
+	columns     map[string][]models.Column
+	foreignKeys map[string][]models.ForeignKey
+	indexes     map[string][]models.Index
+	triggers    map[string][]models.Trigger
+	constraints map[string][]models.Constraint
+	policies    map[string][]models.Policy
+	rowCounts   map[string]models.RowCount
+	stats       map[string]models.TableStats
+}
+
+// tableMetadataTasks returns one task per metadata kind. Each kind is fetched
+// for all tables in a single query, or table by table if that times out, and
+// stored in its own field of the returned tableMetadata. Nested partitions
+// are reported only by their bounds, row counts and activity, so only row
+// counts and statistics are fetched for them.
+func tableMetadataTasks(tables []models.Table, opts runOptions) (*tableMetadata, []func(context.Context, analyzer.DatabaseAnalyzer) error) {
+	metadata := &tableMetadata{}
+	allTables := withPartitions(tables)
+
+	tasks := []func(context.Context, analyzer.DatabaseAnalyzer) error{
+		batched("columns", tables, fetchInto(&metadata.columns, analyzer.DatabaseAnalyzer.GetColumnsForTables)),
+		batched("foreign keys", tables, fetchInto(&metadata.foreignKeys, analyzer.DatabaseAnalyzer.GetForeignKeysForTables)),
+		batched("indexes", tables, fetchInto(&metadata.indexes, analyzer.DatabaseAnalyzer.GetIndexesForTables)),
+		batched("triggers", tables, fetchInto(&metadata.triggers, analyzer.DatabaseAnalyzer.GetTriggersForTables)),
+		batched("constraints", tables, fetchInto(&metadata.constraints, analyzer.DatabaseAnalyzer.GetConstraintsForTables)),
+		batched("policies", tables, fetchInto(&metadata.policies, analyzer.DatabaseAnalyzer.GetPoliciesForTables)),
+		batched("table statistics", allTables, fetchInto(&metadata.stats, analyzer.DatabaseAnalyzer.GetTableStats)),
+	}
+
+	if opts.rowCountMethod != models.RowCountNone {
+		rowCountOpts := analyzer.RowCountOptions{Method: opts.rowCountMethod, Timeout: opts.rowCountTimeout}
+
+		tasks = append(tasks, batched("table row counts", allTables, fetchInto(&metadata.rowCounts,
+			func(databaseAnalyzer analyzer.DatabaseAnalyzer, ctx context.Context, tables []models.Table) (map[string]models.RowCount, error) {
+				return databaseAnalyzer.GetTableRowCounts(ctx, tables, rowCountOpts)
+			})))
+	}
+
+	return metadata, tasks
+}
+
+// apply attaches the collected metadata to the tables, and row counts and
+// statistics to their nested partitions as well.
+func (m *tableMetadata) apply(tables []models.Table) {
+	for i := range tables {
+		key := tables[i].Schema + "." + tables[i].Name
+
+		tables[i].Columns = m.columns[key]
+		tables[i].ForeignKeys = m.foreignKeys[key]
+		tables[i].Indexes = m.indexes[key]
+		tables[i].Triggers = m.triggers[key]
+		tables[i].Constraints = m.constraints[key]
+		tables[i].Policies = m.policies[key]
+	}
+
+	if m.rowCounts != nil {
+		applyRowCounts(tables, m.rowCounts)
 	}
 
-	// Apply row counts to tables
+	applyTableStats(tables, m.stats)
+}
+
+// applyTableStats attaches statistics to tables and their nested partitions.
+func applyTableStats(tables []models.Table, stats map[string]models.TableStats) {
 	for i := range tables {
-		if count, exists := rowCounts[tables[i].Name]; exists {
-			tables[i].RowCount = count
+		if stat, exists := stats[tables[i].Schema+"."+tables[i].Name]; exists {
+			tables[i].Stats = &stat
 		}
+
+		applyTableStats(tables[i].Partitions, stats)
 	}
+}
 
-	return nil
+// applyRowCounts sets row counts on tables and their nested partitions. A
+// partitioned parent stores no rows itself, so it reports the sum of its
+// partitions instead, with the method they share ("mixed" otherwise) and the
+// lowest confidence among them.
+func applyRowCounts(tables []models.Table, rowCounts map[string]models.RowCount) {
+	for i := range tables {
+		table := &tables[i]
+
+		if rowCount, exists := rowCounts[table.Schema+"."+table.Name]; exists {
+			table.RowCount = rowCount.Count
+			table.RowCountMethod = rowCount.Method
+			table.RowCountConfidence = rowCount.Confidence
+		}
+
+		if len(table.Partitions) == 0 {
+			continue
+		}
+
+		applyRowCounts(table.Partitions, rowCounts)
+
+		table.RowCount = 0
+		table.RowCountMethod = table.Partitions[0].RowCountMethod
+		table.RowCountConfidence = table.Partitions[0].RowCountConfidence
+
+		for j := range table.Partitions {
+			partition := &table.Partitions[j]
+			table.RowCount += partition.RowCount
+
+			if partition.RowCountMethod != table.RowCountMethod {
+				table.RowCountMethod = models.RowCountMixed
+			}
+
+			if confidenceRank[partition.RowCountConfidence] < confidenceRank[table.RowCountConfidence] {
+				table.RowCountConfidence = partition.RowCountConfidence
+			}
+		}
+	}
 }
 
-func generateAndWriteDocumentation(schema *models.Schema, format, output string) error {
+// sumPartitionSizes sets the sizes of each partitioned parent, which has no
+// storage of its own, to the sum of its partitions' sizes.
+func sumPartitionSizes(tables []models.Table) {
+	for i := range tables {
+		table := &tables[i]
+		if len(table.Partitions) == 0 {
+			continue
+		}
+
+		sumPartitionSizes(table.Partitions)
+
+		table.HeapSizeBytes, table.ToastSizeBytes, table.IndexesSizeBytes, table.TotalSizeBytes = 0, 0, 0, 0
+
+		for j := range table.Partitions {
+			partition := &table.Partitions[j]
+			table.HeapSizeBytes += partition.HeapSizeBytes
+			table.ToastSizeBytes += partition.ToastSizeBytes
+			table.IndexesSizeBytes += partition.IndexesSizeBytes
+			table.TotalSizeBytes += partition.TotalSizeBytes
+		}
+	}
+}
+
+var confidenceRank = map[string]int{
+	models.ConfidenceLow:    1,
+	models.ConfidenceMedium: 2,
+	models.ConfidenceHigh:   3,
+}
+
+// organizePartitions attaches each partition to its parent's Partitions,
+// recursively for sub-partitioned tables. Unless expand is set, partitions
+// whose parent is documented are dropped from the top-level list so a
+// partitioned table appears once.
+func organizePartitions(tables []models.Table, expand bool) []models.Table {
+	index := make(map[string]int, len(tables))
+	for i := range tables {
+		index[tables[i].Schema+"."+tables[i].Name] = i
+	}
+
+	children := make(map[int][]int)
+
+	for i := range tables {
+		if parent, exists := index[tables[i].PartitionOf]; exists {
+			children[parent] = append(children[parent], i)
+		}
+	}
+
+	if len(children) == 0 {
+		return tables
+	}
+
+	var build func(i int) models.Table
+
+	build = func(i int) models.Table {
+		table := tables[i]
+		table.Partitions = nil
+
+		for _, child := range children[i] {
+			table.Partitions = append(table.Partitions, build(child))
+		}
+
+		return table
+	}
+
+	organized := make([]models.Table, 0, len(tables))
+
+	for i := range tables {
+		_, hasParent := index[tables[i].PartitionOf]
+		if hasParent && !expand {
+			continue
+		}
+
+		organized = append(organized, build(i))
+	}
+
+	return organized
+}
+
+// withPartitions flattens nested partitions into a single list, skipping
+// partitions that are also listed at the top level.
+func withPartitions(tables []models.Table) []models.Table {
+	var (
+		all     []models.Table
+		flatten func(tables []models.Table)
+	)
+
+	seen := make(map[string]bool)
+	flatten = func(tables []models.Table) {
+		for i := range tables {
+			key := tables[i].Schema + "." + tables[i].Name
+			if !seen[key] {
+				seen[key] = true
+
+				all = append(all, tables[i])
+			}
+
+			flatten(tables[i].Partitions)
+		}
+	}
+
+	flatten(tables)
+
+	return all
+}
+
+func generateAndWriteDocumentation(schema *models.Schema, opts runOptions) error {
 	log.Println("Generating documentation...")
 
-	documentation, err := generateDocumentation(schema, format)
+	documentation, err := generateDocumentation(schema, opts)
 	if err != nil {
 		return err
 	}
 
-	if err := os.WriteFile(output, []byte(documentation), 0o600); err != nil {
+	if err := os.WriteFile(opts.output, []byte(documentation), 0o600); err != nil {
 		return fmt.Errorf("failed to write output file: %w", err)
 	}
 
-	log.Printf("Documentation written to %s\n", output)
+	log.Printf("Documentation written to %s\n", opts.output)
 
 	return nil
 }
 
-func generateDocumentation(schema *models.Schema, format string) (string, error) {
-	switch format {
+func generateDocumentation(schema *models.Schema, opts runOptions) (string, error) {
+	switch opts.format {
 	case "markdown":
 		markdownReporter := reporter.NewMarkdownReporter()
+		markdownReporter.SetSequenceThresholds(opts.sequenceWarning, opts.sequenceCritical)
+
 		return markdownReporter.Generate(schema)
 	case "json":
 		jsonReporter := reporter.NewJSONReporter()
 		return jsonReporter.Generate(schema)
 	default:
-		return "", fmt.Errorf("unsupported format: %s", format)
+		return "", fmt.Errorf("unsupported format: %s", opts.format)
 	}
 }
@@ -1,7 +1,18 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/orchard9/pg-goer/internal/analyzer"
+	"github.com/orchard9/pg-goer/pkg/models"
 )
 
 func TestMain(t *testing.T) {
@@ -17,3 +28,451 @@ func TestMain(t *testing.T) {
 		}
 	})
 }
+
+func TestOrganizePartitions(t *testing.T) {
+	tables := []models.Table{
+		{Schema: "public", Name: "events", PartitionStrategy: "RANGE", PartitionKey: "created_at"},
+		{Schema: "public", Name: "events_2024", PartitionOf: "public.events", PartitionBound: "FOR VALUES FROM ('2024-01-01') TO ('2025-01-01')", PartitionStrategy: "LIST", PartitionKey: "region"},
+		{Schema: "public", Name: "events_2024_eu", PartitionOf: "public.events_2024", PartitionBound: "FOR VALUES IN ('eu')"},
+		{Schema: "public", Name: "events_2025", PartitionOf: "public.events", PartitionBound: "FOR VALUES FROM ('2025-01-01') TO ('2026-01-01')"},
+		{Schema: "public", Name: "users"},
+	}
+
+	t.Run("collapsed", func(t *testing.T) {
+		organized := organizePartitions(tables, false)
+
+		if len(organized) != 2 {
+			t.Fatalf("expected 2 top-level tables, got %d", len(organized))
+		}
+
+		events := organized[0]
+		if len(events.Partitions) != 2 {
+			t.Fatalf("expected 2 partitions under events, got %d", len(events.Partitions))
+		}
+
+		if len(events.Partitions[0].Partitions) != 1 || events.Partitions[0].Partitions[0].Name != "events_2024_eu" {
+			t.Errorf("expected events_2024_eu nested under events_2024, got %+v", events.Partitions[0].Partitions)
+		}
+
+		rowCounts := map[string]models.RowCount{
+			"public.events_2024_eu": {Count: 10, Method: models.RowCountExact, Confidence: models.ConfidenceHigh},
+			"public.events_2025":    {Count: 5, Method: models.RowCountEstimate, Confidence: models.ConfidenceMedium},
+			"public.users":          {Count: 3, Method: models.RowCountExact, Confidence: models.ConfidenceHigh},
+		}
+		applyRowCounts(organized, rowCounts)
+
+		if organized[0].RowCount != 15 {
+			t.Errorf("expected parent row count to sum partitions to 15, got %d", organized[0].RowCount)
+		}
+
+		if organized[0].RowCountMethod != models.RowCountMixed || organized[0].RowCountConfidence != models.ConfidenceMedium {
+			t.Errorf("expected parent to report mixed method with medium confidence, got %s/%s",
+				organized[0].RowCountMethod, organized[0].RowCountConfidence)
+		}
+
+		if organized[1].RowCountMethod != models.RowCountExact {
+			t.Errorf("expected users to keep its exact count method, got %s", organized[1].RowCountMethod)
+		}
+
+		organized[0].Partitions[0].Partitions[0].HeapSizeBytes = 8192
+		organized[0].Partitions[0].Partitions[0].TotalSizeBytes = 16384
+		organized[0].Partitions[1].HeapSizeBytes = 8192
+		organized[0].Partitions[1].TotalSizeBytes = 24576
+		sumPartitionSizes(organized)
+
+		if organized[0].HeapSizeBytes != 16384 || organized[0].TotalSizeBytes != 40960 {
+			t.Errorf("expected parent sizes to sum partitions to 16384/40960, got %d/%d",
+				organized[0].HeapSizeBytes, organized[0].TotalSizeBytes)
+		}
+	})
+
+	t.Run("table stats reach nested partitions", func(t *testing.T) {
+		organized := organizePartitions(tables, false)
+
+		applyTableStats(organized, map[string]models.TableStats{
+			"public.events_2024_eu": {TuplesInserted: 7},
+		})
+
+		eu := organized[0].Partitions[0].Partitions[0]
+		if eu.Stats == nil || eu.Stats.TuplesInserted != 7 {
+			t.Errorf("expected events_2024_eu to carry its statistics, got %+v", eu.Stats)
+		}
+
+		if organized[0].Stats != nil {
+			t.Errorf("expected parent without statistics to stay nil, got %+v", organized[0].Stats)
+		}
+	})
+
+	t.Run("expanded", func(t *testing.T) {
+		organized := organizePartitions(tables, true)
+
+		if len(organized) != len(tables) {
+			t.Fatalf("expected all %d tables at top level, got %d", len(tables), len(organized))
+		}
+
+		if len(organized[0].Partitions) != 2 {
+			t.Errorf("expected parent to still list its partitions, got %d", len(organized[0].Partitions))
+		}
+
+		if flattened := withPartitions(organized); len(flattened) != len(tables) {
+			t.Errorf("expected %d distinct tables when flattened, got %d", len(tables), len(flattened))
+		}
+	})
+}
+
+func TestApplyRowCountsAcrossSchemas(t *testing.T) {
+	tables := []models.Table{
+		{Schema: "public", Name: "orders"},
+		{Schema: "audit", Name: "orders"},
+	}
+
+	applyRowCounts(tables, map[string]models.RowCount{
+		"public.orders": {Count: 120, Method: models.RowCountExact, Confidence: models.ConfidenceHigh},
+		"audit.orders":  {Count: 4, Method: models.RowCountEstimate, Confidence: models.ConfidenceLow},
+	})
+
+	if tables[0].RowCount != 120 || tables[1].RowCount != 4 {
+		t.Errorf("expected 120 and 4 rows, got %d and %d", tables[0].RowCount, tables[1].RowCount)
+	}
+}
+
+func TestApplyTableStatsAcrossSchemas(t *testing.T) {
+	tables := []models.Table{
+		{Schema: "public", Name: "orders"},
+		{Schema: "audit", Name: "orders"},
+	}
+
+	applyTableStats(tables, map[string]models.TableStats{
+		"public.orders": {SeqScans: 900},
+		"audit.orders":  {SeqScans: 2},
+	})
+
+	if tables[0].Stats == nil || tables[0].Stats.SeqScans != 900 || tables[1].Stats == nil || tables[1].Stats.SeqScans != 2 {
+		t.Errorf("expected each schema's orders to keep its own statistics, got %+v and %+v", tables[0].Stats, tables[1].Stats)
+	}
+}
+
+func TestTableMetadataApplyToPartitions(t *testing.T) {
+	tables := []models.Table{{
+		Schema: "public",
+		Name:   "events",
+		Partitions: []models.Table{{
+			Schema:      "public",
+			Name:        "events_2024",
+			PartitionOf: "public.events",
+			Partitions:  []models.Table{{Schema: "public", Name: "events_2024_01", PartitionOf: "public.events_2024"}},
+		}},
+	}}
+
+	metadata := &tableMetadata{
+		columns: map[string][]models.Column{"public.events": {{Name: "id"}}},
+		rowCounts: map[string]models.RowCount{
+			"public.events_2024_01": {Count: 7, Method: models.RowCountExact, Confidence: models.ConfidenceHigh},
+		},
+		stats: map[string]models.TableStats{"public.events_2024_01": {SeqScans: 3}},
+	}
+
+	metadata.apply(tables)
+
+	if len(tables[0].Columns) != 1 {
+		t.Errorf("expected the parent to get its columns, got %+v", tables[0].Columns)
+	}
+
+	leaf := tables[0].Partitions[0].Partitions[0]
+	if leaf.RowCount != 7 || leaf.Stats == nil || leaf.Stats.SeqScans != 3 {
+		t.Errorf("expected the sub-partition to get its row count and statistics, got %+v", leaf)
+	}
+
+	if tables[0].RowCount != 7 {
+		t.Errorf("expected the parent to sum its partitions' rows, got %d", tables[0].RowCount)
+	}
+}
+
+func TestFilterGrants(t *testing.T) {
+	grants := []models.Grant{
+		{Grantee: "app", Privilege: "SELECT"},
+		{Grantee: "pg_read_all_data", Privilege: "SELECT", IsSystemRole: true},
+		{Grantee: "legacy", Privilege: "SELECT"},
+	}
+
+	tests := []struct {
+		name               string
+		includeSystemRoles bool
+		excludeRoles       []string
+		expected           []string
+	}{
+		{name: "system roles excluded by default", expected: []string{"app", "legacy"}},
+		{name: "system roles included", includeSystemRoles: true, expected: []string{"app", "pg_read_all_data", "legacy"}},
+		{name: "explicit exclusions", includeSystemRoles: true, excludeRoles: []string{"legacy"}, expected: []string{"app", "pg_read_all_data"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filtered := filterGrants(grants, tt.includeSystemRoles, tt.excludeRoles)
+
+			if len(filtered) != len(tt.expected) {
+				t.Fatalf("expected %d grants, got %d", len(tt.expected), len(filtered))
+			}
+
+			for i, grantee := range tt.expected {
+				if filtered[i].Grantee != grantee {
+					t.Errorf("expected grant %d to be held by %s, got %s", i, grantee, filtered[i].Grantee)
+				}
+			}
+		})
+	}
+}
+
+func TestApplyPublications(t *testing.T) {
+	tables := []models.Table{
+		{
+			Schema: "public",
+			Name:   "events",
+			Partitions: []models.Table{
+				{Schema: "public", Name: "events_2024", PartitionOf: "public.events"},
+			},
+		},
+		{Schema: "public", Name: "orders"},
+		{Schema: "audit", Name: "orders"},
+	}
+
+	publications := []models.Publication{
+		{Name: "cdc", Tables: []string{"public.events_2024", "public.orders"}},
+		{Name: "everything", AllTables: true, Tables: []string{"audit.orders", "public.events_2024", "public.orders"}},
+	}
+
+	applyPublications(tables, publications)
+
+	tests := []struct {
+		name     string
+		table    models.Table
+		expected []string
+	}{
+		{name: "unpublished parent", table: tables[0], expected: nil},
+		{name: "nested partition", table: tables[0].Partitions[0], expected: []string{"cdc", "everything"}},
+		{name: "published table", table: tables[1], expected: []string{"cdc", "everything"}},
+		{name: "same name in another schema", table: tables[2], expected: []string{"everything"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if strings.Join(tt.table.Publications, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("expected publications %v, got %v", tt.expected, tt.table.Publications)
+			}
+		})
+	}
+}
+
+func TestRunTasks(t *testing.T) {
+	t.Run("runs every task on a worker of its own", func(t *testing.T) {
+		var (
+			running, peak, done atomic.Int32
+			busy                [3]atomic.Bool
+		)
+
+		tasks := make([]func(context.Context, int) error, 20)
+		for i := range tasks {
+			tasks[i] = func(_ context.Context, worker int) error {
+				if busy[worker].Swap(true) {
+					t.Errorf("worker %d handed to two tasks at once", worker)
+				}
+				defer busy[worker].Store(false)
+
+				current := running.Add(1)
+				defer running.Add(-1)
+
+				for {
+					highest := peak.Load()
+					if current <= highest || peak.CompareAndSwap(highest, current) {
+						break
+					}
+				}
+
+				time.Sleep(time.Millisecond)
+				done.Add(1)
+
+				return nil
+			}
+		}
+
+		if err := runTasks(context.Background(), []int{0, 1, 2}, tasks); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if done.Load() != 20 {
+			t.Errorf("expected 20 tasks to run, got %d", done.Load())
+		}
+
+		if peak.Load() > 3 {
+			t.Errorf("expected at most 3 tasks at once, got %d", peak.Load())
+		}
+	})
+
+	t.Run("first error cancels the remaining tasks", func(t *testing.T) {
+		failure := errors.New("permission denied")
+
+		var started atomic.Int32
+
+		tasks := []func(context.Context, int) error{
+			func(context.Context, int) error { return failure },
+		}
+
+		for range 10 {
+			tasks = append(tasks, func(ctx context.Context, _ int) error {
+				started.Add(1)
+
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-time.After(5 * time.Second):
+					return nil
+				}
+			})
+		}
+
+		err := runTasks(context.Background(), []int{1, 2}, tasks)
+		if !errors.Is(err, failure) {
+			t.Fatalf("expected %v, got %v", failure, err)
+		}
+
+		if started.Load() == 10 {
+			t.Error("expected tasks queued after the failure not to start")
+		}
+	})
+
+	t.Run("cancelled parent context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := runTasks(ctx, []int{1, 2}, []func(context.Context, int) error{
+			func(context.Context, int) error { return nil },
+		})
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+	})
+}
+
+func TestExtract(t *testing.T) {
+	workers := []worker{{conn: &analyzer.Connection{}}, {conn: &analyzer.Connection{}}}
+	timeout := fmt.Errorf("failed to get columns for view public.report: %w", &pq.Error{Code: "57014", Message: "canceling statement due to statement timeout"})
+
+	t.Run("timeouts become warnings", func(t *testing.T) {
+		var (
+			warnings warningLog
+			finished atomic.Int32
+		)
+
+		err := extract(context.Background(), workers, &warnings, []func(context.Context, analyzer.DatabaseAnalyzer) error{
+			func(context.Context, analyzer.DatabaseAnalyzer) error { return timeout },
+			func(context.Context, analyzer.DatabaseAnalyzer) error { finished.Add(1); return nil },
+			func(context.Context, analyzer.DatabaseAnalyzer) error { finished.Add(1); return nil },
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if finished.Load() != 2 {
+			t.Errorf("expected the other tasks to finish, %d did", finished.Load())
+		}
+
+		got := warnings.sorted()
+		if len(got) != 1 || got[0].Reason != models.WarningTimeout || !strings.Contains(got[0].Message, "public.report") {
+			t.Errorf("expected one timeout warning naming the view, got %+v", got)
+		}
+	})
+
+	t.Run("missing privileges become warnings", func(t *testing.T) {
+		var warnings warningLog
+
+		denied := fmt.Errorf("failed to get table statistics: %w", &pq.Error{Code: "42501", Message: "permission denied for table pg_stat_user_tables"})
+
+		err := extract(context.Background(), workers, &warnings, []func(context.Context, analyzer.DatabaseAnalyzer) error{
+			func(context.Context, analyzer.DatabaseAnalyzer) error { return denied },
+			func(context.Context, analyzer.DatabaseAnalyzer) error { return timeout },
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		got := warnings.sorted()
+		if len(got) != 2 || got[0].Reason != models.WarningTimeout || got[1].Reason != models.WarningPermissionDenied {
+			t.Errorf("expected warnings ordered by message, got %+v", got)
+		}
+	})
+
+	t.Run("other errors fail the run", func(t *testing.T) {
+		var warnings warningLog
+
+		failure := errors.New("connection reset")
+
+		err := extract(context.Background(), workers, &warnings, []func(context.Context, analyzer.DatabaseAnalyzer) error{
+			func(context.Context, analyzer.DatabaseAnalyzer) error { return failure },
+		})
+		if !errors.Is(err, failure) {
+			t.Errorf("expected %v, got %v", failure, err)
+		}
+
+		if len(warnings.sorted()) != 0 {
+			t.Errorf("expected no warnings, got %+v", warnings.sorted())
+		}
+	})
+
+	t.Run("timed out batches are retried per table", func(t *testing.T) {
+		var (
+			warnings warningLog
+			columns  map[string][]models.Column
+		)
+
+		tables := []models.Table{{Schema: "public", Name: "users"}, {Schema: "public", Name: "events"}, {Schema: "public", Name: "orders"}}
+		getColumns := func(_ analyzer.DatabaseAnalyzer, _ context.Context, tables []models.Table) (map[string][]models.Column, error) {
+			columns := make(map[string][]models.Column)
+
+			for _, table := range tables {
+				if table.Name == "events" {
+					return nil, &pq.Error{Code: "57014", Message: "canceling statement due to statement timeout"}
+				}
+
+				columns[table.Schema+"."+table.Name] = []models.Column{{Name: "id"}}
+			}
+
+			return columns, nil
+		}
+
+		err := extract(context.Background(), workers, &warnings, []func(context.Context, analyzer.DatabaseAnalyzer) error{
+			batched("columns", tables, fetchInto(&columns, getColumns)),
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(columns) != 2 || columns["public.users"] == nil || columns["public.orders"] == nil {
+			t.Errorf("expected the other tables to keep their columns, got %+v", columns)
+		}
+
+		got := warnings.sorted()
+		if len(got) != 1 || got[0].Reason != models.WarningTimeout || !strings.Contains(got[0].Message, "for table public.events") {
+			t.Errorf("expected one timeout warning naming the slow table, got %+v", got)
+		}
+	})
+}
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{name: "complete report", err: nil, expected: exitSuccess},
+		{name: "report with gaps", err: fmt.Errorf("%w: 2 parts of the schema could not be read", errIncomplete), expected: exitPartial},
+		{name: "failed run", err: errors.New("failed to connect to database"), expected: exitFailure},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := exitCode(tt.err); got != tt.expected {
+				t.Errorf("expected exit code %d, got %d", tt.expected, got)
+			}
+		})
+	}
+}
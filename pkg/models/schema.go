@@ -1,77 +1,504 @@
 package models
 
+import "time"
+
 type Schema struct {
-	Name       string
-	Tables     []Table
-	Views      []View
-	Sequences  []Sequence
-	Extensions []Extension
+	Name              string
+	Database          *DatabaseInfo // server and database overview; nil when not collected
+	Snapshot          *Snapshot     // the consistent view the schema was read from; nil when not read in one
+	Warnings          []Warning     // parts of the schema that could not be read
+	Tables            []Table
+	Views             []View
+	MaterializedViews []MaterializedView
+	Sequences         []Sequence
+	Routines          []Routine
+	Extensions        []Extension
+	Types             []Type
+	Grants            []Grant
+	SizeBytes         int64 // on-disk size of the whole database
+
+	// Database-level objects that act on data or DDL outside any one table.
+	EventTriggers []EventTrigger
+	Rules         []Rule
+	Publications  []Publication
+
+	// Foreign data: wrappers, the servers defined on them and the foreign
+	// tables whose rows live on those servers.
+	ForeignDataWrappers []ForeignDataWrapper
+	ForeignServers      []ForeignServer
+	ForeignTables       []ForeignTable
 }
 
 type Table struct {
 	Schema      string
 	Name        string
+	Description string
 	Columns     []Column
 	ForeignKeys []ForeignKey
 	Indexes     []Index
 	Triggers    []Trigger
+	Constraints []Constraint
 	RowCount    int64
+
+	// How RowCount was obtained and how far it can be trusted. Empty when
+	// row counts were not collected.
+	RowCountMethod     RowCountMethod
+	RowCountConfidence string
+
+	// On-disk sizes. TotalSizeBytes covers the heap, TOAST and all indexes.
+	HeapSizeBytes    int64
+	ToastSizeBytes   int64
+	IndexesSizeBytes int64
+	TotalSizeBytes   int64
+
+	// Activity and maintenance statistics; nil when the database does not
+	// track them.
+	Stats *TableStats
+
+	// Declarative partitioning. PartitionStrategy and PartitionKey are set on
+	// partitioned parents; PartitionOf and PartitionBound on partitions.
+	PartitionStrategy string
+	PartitionKey      string
+	PartitionOf       string
+	PartitionBound    string
+	Partitions        []Table
+
+	// Row-level security. Policies only apply while RowSecurity is enabled;
+	// ForceRowSecurity also applies them to the table owner.
+	RowSecurity      bool
+	ForceRowSecurity bool
+	Policies         []Policy
+
+	// Names of the logical replication publications that include the table.
+	Publications []string
+}
+
+// TableStats holds the cumulative activity counters and maintenance times the
+// statistics collector keeps for a table since its statistics were last reset.
+type TableStats struct {
+	SeqScans       int64
+	IndexScans     int64
+	TuplesInserted int64
+	TuplesUpdated  int64
+	TuplesDeleted  int64
+	LiveTuples     int64
+	DeadTuples     int64
+
+	LastVacuum      *time.Time
+	LastAutovacuum  *time.Time
+	LastAnalyze     *time.Time
+	LastAutoanalyze *time.Time
+
+	// Buffer cache hits and disk block reads, for heap and index pages.
+	HeapBlocksHit   int64
+	HeapBlocksRead  int64
+	IndexBlocksHit  int64
+	IndexBlocksRead int64
+}
+
+// RowCountMethod selects how table row counts are obtained.
+type RowCountMethod string
+
+const (
+	// RowCountEstimate reads the planner statistics; free but only as fresh as the last ANALYZE.
+	RowCountEstimate RowCountMethod = "estimate"
+	// RowCountExact runs COUNT(*) on every table.
+	RowCountExact RowCountMethod = "exact"
+	// RowCountSample counts a sample of pages and scales the result up.
+	RowCountSample RowCountMethod = "sample"
+	// RowCountNone skips row counts entirely.
+	RowCountNone RowCountMethod = "none"
+	// RowCountMixed marks a partitioned table whose partitions were counted
+	// with different methods.
+	RowCountMixed RowCountMethod = "mixed"
+)
+
+// Row count confidence levels.
+const (
+	ConfidenceHigh   = "high"
+	ConfidenceMedium = "medium"
+	ConfidenceLow    = "low"
+)
+
+// RowCount is a table's row count together with the method that produced it.
+type RowCount struct {
+	Count      int64
+	Method     RowCountMethod
+	Confidence string
 }
 
 type Column struct {
 	Name         string
 	DataType     string
+	TypeRef      string // schema-qualified user-defined type, if any
+	Description  string
 	IsNullable   bool
 	DefaultValue *string
 	IsPrimaryKey bool
 	IsUnique     bool
 	MaxLength    *int
+
+	// Type modifiers. Precision is set for numeric types declared with a
+	// precision and for time types with fractional seconds; Scale only for
+	// numeric types. ArrayDimensions is the declared number of dimensions.
+	Precision       *int
+	Scale           *int
+	ArrayDimensions int
+	Collation       string // only set when it differs from the default
+
+	// Identity is "ALWAYS" or "BY DEFAULT" for identity columns and
+	// "AUTO_INCREMENT" for MariaDB auto-increment columns.
+	Identity string
+	// Generated is "STORED" or "VIRTUAL" for generated columns, whose value
+	// is computed from GenerationExpression.
+	Generated            string
+	GenerationExpression string
 }
 
+// Column identity kinds.
+const (
+	IdentityAlways        = "ALWAYS"
+	IdentityByDefault     = "BY DEFAULT"
+	IdentityAutoIncrement = "AUTO_INCREMENT"
+)
+
+// ForeignKey describes a single foreign key constraint. Composite keys keep
+// their column pairs in constraint order, so SourceColumns[i] references
+// ReferencedColumns[i].
 type ForeignKey struct {
-	Name             string
-	SourceTable      string
-	SourceColumn     string
-	ReferencedTable  string
-	ReferencedColumn string
-	OnDelete         string
-	OnUpdate         string
+	Name              string
+	SourceTable       string
+	SourceColumns     []string
+	ReferencedTable   string
+	ReferencedColumns []string
+	OnDelete          string
+	OnUpdate          string
 }
 
+// Constraint describes a table constraint as the database reports it.
+// Definition holds the full constraint text, e.g. "CHECK ((price > 0))".
+type Constraint struct {
+	Name         string
+	Type         string
+	Columns      []string
+	Definition   string
+	IsDeferrable bool
+	IsDeferred   bool
+	IsValidated  bool
+}
+
+// Policy is a row-level security policy. Command is ALL, SELECT, INSERT,
+// UPDATE or DELETE; Using and WithCheck are empty when not set.
+type Policy struct {
+	Name         string
+	Command      string
+	IsPermissive bool
+	Roles        []string
+	Using        string
+	WithCheck    string
+}
+
+// Index describes an index. Columns lists the key columns or expressions in
+// index order; KeyParts carries the same entries with their sort order and
+// operator class.
 type Index struct {
-	Name      string
-	Type      string
-	IsPrimary bool
-	IsUnique  bool
-	Columns   []string
-	Method    string
+	Name       string
+	Type       string
+	IsPrimary  bool
+	IsUnique   bool
+	Columns    []string
+	Method     string
+	Definition string
+	KeyParts   []IndexKeyPart
+	Include    []string
+	Predicate  string
+	IsValid    bool
+	SizeBytes  int64
 }
 
+// IndexKeyPart is one key of an index: either a column or an expression.
+// OpClass is only set when the index uses a non-default operator class.
+type IndexKeyPart struct {
+	Column     string
+	Expression string
+	Descending bool
+	NullsFirst bool
+	OpClass    string
+}
+
+// Trigger describes a table or view trigger. Event lists the firing events
+// separated by commas; Columns narrows UPDATE to UPDATE OF those columns.
+// Timing is "BEFORE", "AFTER" or "INSTEAD OF".
 type Trigger struct {
+	Name           string
+	Event          string
+	Timing         string
+	Function       string
+	FunctionSchema string
+	Orientation    string
+
+	Definition   string // full CREATE TRIGGER statement
+	Condition    string // WHEN condition, without the parentheses
+	Columns      []string
+	OldTable     string // REFERENCING OLD TABLE AS name
+	NewTable     string // REFERENCING NEW TABLE AS name
+	IsConstraint bool
+	Enabled      string // one of the TriggerEnabled constants
+	ActionOrder  int    // firing order among triggers with the same timing and event (MariaDB)
+}
+
+// Trigger firing states, following ALTER TABLE ... ENABLE/DISABLE TRIGGER.
+// Replica triggers only fire when session_replication_role is "replica";
+// always triggers fire regardless of it.
+const (
+	TriggerEnabled  = "ENABLED"
+	TriggerDisabled = "DISABLED"
+	TriggerReplica  = "REPLICA"
+	TriggerAlways   = "ALWAYS"
+)
+
+// Routine describes a function, procedure or aggregate. Kind is "function",
+// "procedure", "aggregate" or "window". Source is only filled when the
+// caller asks for routine bodies.
+type Routine struct {
+	Schema          string
+	Name            string
+	Kind            string
+	Arguments       string
+	ReturnType      string
+	Language        string
+	Volatility      string
+	SecurityDefiner bool
+	Owner           string
+	Description     string
+	Source          string
+}
+
+// Type describes a user-defined type. Kind is "enum", "domain", "composite"
+// or "range"; only the fields for that kind are set.
+type Type struct {
+	Schema      string
+	Name        string
+	Kind        string
+	Description string
+
+	Labels       []string        // enum labels in sort order
+	BaseType     string          // domain base type
+	DefaultValue *string         // domain default
+	NotNull      bool            // domain NOT NULL
+	Constraints  []string        // domain CHECK constraints
+	Attributes   []TypeAttribute // composite attributes
+	Subtype      string          // range subtype
+}
+
+type TypeAttribute struct {
+	Name     string
+	DataType string
+}
+
+// Grant is one privilege held by a role on a database object. ObjectType is
+// "table", "view", "materialized view", "foreign table", "sequence",
+// "column", "function" or "schema"; Column is only set for column grants.
+type Grant struct {
+	ObjectType   string
+	Schema       string
+	Object       string
+	Column       string
+	Grantee      string
+	Grantor      string
+	Privilege    string
+	IsGrantable  bool
+	IsSystemRole bool
+}
+
+// EventTrigger fires a function on DDL events such as ddl_command_end. Tags
+// limits it to the listed command tags; it fires for every command when empty.
+type EventTrigger struct {
+	Name           string
+	Event          string
+	Tags           []string
+	Function       string
+	FunctionSchema string
+	Enabled        string // one of the TriggerEnabled constants
+	Owner          string
+	Description    string
+}
+
+// Rule is a query rewrite rule on a table or view. A view's own _RETURN rule
+// is its definition and is not reported as a rule.
+type Rule struct {
+	Schema     string
+	Table      string
+	Name       string
+	Event      string // SELECT, INSERT, UPDATE or DELETE
+	IsInstead  bool
+	Enabled    string // one of the TriggerEnabled constants
+	Definition string
+}
+
+// Publication is a logical replication publication. Tables lists every
+// published table, schema-qualified, including those covered by FOR ALL
+// TABLES or FOR TABLES IN SCHEMA.
+type Publication struct {
+	Name       string
+	Owner      string
+	AllTables  bool
+	Operations []string // published operations: INSERT, UPDATE, DELETE, TRUNCATE
+	Tables     []string
+}
+
+type ForeignDataWrapper struct {
+	Name      string
+	Owner     string
+	Handler   string
+	Validator string
+	Options   []string // "name=value" pairs
+}
+
+// ForeignServer is a remote data source. Options hold only option names
+// known not to carry credentials; the rest are stripped before they reach
+// the model.
+type ForeignServer struct {
+	Name         string
+	Wrapper      string
+	Type         string
+	Version      string
+	Owner        string
+	Options      []string
+	UserMappings []UserMapping
+}
+
+// UserMapping maps a local role to credentials on a foreign server. User is
+// "PUBLIC" for the mapping that applies to every role. Options lists the
+// names of the mapping's options only; their values are never kept.
+type UserMapping struct {
+	User    string
+	Options []string
+}
+
+// ForeignTable is a table whose rows are read from and written to a foreign
+// server. Options name the remote object, e.g. "table_name=orders".
+type ForeignTable struct {
+	Schema      string
 	Name        string
-	Event       string
-	Timing      string
-	Function    string
-	Orientation string
+	Description string
+	Server      string
+	Options     []string
+	Columns     []Column
+}
+
+// DatabaseInfo describes the documented database and the server hosting it.
+type DatabaseInfo struct {
+	Engine        string // "PostgreSQL", "MariaDB" or "MySQL"
+	ServerVersion string
+	Name          string
+	Owner         string
+	Encoding      string
+	Collation     string
+	CType         string
+	TimeZone      string
+	Settings      []Setting // server settings changed from their built-in defaults
+	Schemas       []SchemaInfo
+}
+
+// Snapshot identifies the read-only REPEATABLE READ transaction snapshot a
+// report was extracted from. ID is the exported PostgreSQL snapshot; MariaDB
+// cannot export snapshots and leaves it empty.
+type Snapshot struct {
+	ID      string
+	TakenAt time.Time
+}
+
+// Warning records part of the schema that could not be read, leaving a gap
+// in the documentation. Message names the object and the underlying error.
+type Warning struct {
+	Reason  string
+	Message string
+}
+
+// Warning reasons.
+const (
+	WarningTimeout          = "timeout"
+	WarningPermissionDenied = "permission denied"
+)
+
+// Setting is a server setting whose value was set outside the built-in
+// defaults. Source records where, e.g. "configuration file".
+type Setting struct {
+	Name   string
+	Value  string
+	Source string
+}
+
+type SchemaInfo struct {
+	Name        string
+	Owner       string
+	Description string
 }
 
 type Extension struct {
 	Name    string
 	Version string
 	Schema  string
+
+	// DefaultVersion is the version ALTER EXTENSION ... UPDATE would install;
+	// it differs from Version when an upgrade is available.
+	DefaultVersion string
+}
+
+// HasUpgrade reports whether a newer version of the extension is available.
+func (e *Extension) HasUpgrade() bool {
+	return e.DefaultVersion != "" && e.DefaultVersion != e.Version
 }
 
 type View struct {
+	Schema       string
+	Name         string
+	Description  string
+	Definition   string
+	Columns      []Column
+	Dependencies []Dependency
+	Triggers     []Trigger // INSTEAD OF and statement-level triggers
+}
+
+type MaterializedView struct {
+	Schema       string
+	Name         string
+	Description  string
+	Definition   string
+	Columns      []Column
+	Indexes      []Index
+	Dependencies []Dependency
+	IsPopulated  bool
+	SizeBytes    int64
+}
+
+// Dependency identifies a relation another object reads from. Kind is the
+// relation kind, e.g. "table" or "view".
+type Dependency struct {
 	Schema string
 	Name   string
+	Kind   string
 }
 
 type Sequence struct {
-	Schema     string
-	Name       string
-	DataType   string
-	StartValue int64
-	MinValue   int64
-	MaxValue   int64
-	Increment  int64
+	Schema      string
+	Name        string
+	DataType    string
+	StartValue  int64
+	MinValue    int64
+	MaxValue    int64
+	Increment   int64
+	Description string
+
+	// LastValue is nil until the sequence is first used or when the
+	// current role may not read it.
+	LastValue   *int64
+	PercentUsed float64
+
+	// The column the sequence feeds, if it is owned by one through a serial
+	// or OWNED BY declaration, or backs an identity column.
+	OwnerTable  string // schema-qualified
+	OwnerColumn string
+	IsIdentity  bool
 }
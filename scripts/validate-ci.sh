@@ -63,7 +63,7 @@ fi
 
 echo ""
 echo "🏗️  4. Testing build process..."
-go build -o pg-goer ./cmd/pg-goer
+go build -o pg-goer .
 echo "✅ Build successful"
 
 echo ""
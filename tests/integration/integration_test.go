@@ -194,8 +194,8 @@ func TestSchemaAnalysis(t *testing.T) {
 
 	if len(postsForeignKeys) > 0 {
 		fk := postsForeignKeys[0]
-		if fk.SourceColumn != "author_id" {
-			t.Errorf("Expected foreign key on author_id, got %s", fk.SourceColumn)
+		if len(fk.SourceColumns) != 1 || fk.SourceColumns[0] != "author_id" {
+			t.Errorf("Expected foreign key on author_id, got %v", fk.SourceColumns)
 		}
 		if !strings.Contains(fk.ReferencedTable, "users") {
 			t.Errorf("Expected foreign key to reference users table, got %s", fk.ReferencedTable)
@@ -219,7 +219,7 @@ func TestRowCountAnalysis(t *testing.T) {
 		t.Fatalf("Failed to get tables: %v", err)
 	}
 
-	rowCounts, err := schemaAnalyzer.GetTableRowCounts(ctx, tables)
+	rowCounts, err := schemaAnalyzer.GetTableRowCounts(ctx, tables, analyzer.RowCountOptions{Method: models.RowCountExact})
 	if err != nil {
 		t.Fatalf("Failed to get row counts: %v", err)
 	}
@@ -234,14 +234,206 @@ func TestRowCountAnalysis(t *testing.T) {
 	}
 
 	for tableName, expectedCount := range expectedRowCounts {
-		if count, exists := rowCounts[tableName]; !exists {
+		if rowCount, exists := rowCounts["public."+tableName]; !exists {
 			t.Errorf("Row count not found for table '%s'", tableName)
-		} else if count != expectedCount {
-			t.Errorf("Expected %d rows in '%s', got %d", expectedCount, tableName, count)
+		} else if rowCount.Count != expectedCount {
+			t.Errorf("Expected %d rows in '%s', got %d", expectedCount, tableName, rowCount.Count)
 		}
 	}
 }
 
+// TestBulkMetadataMatchesPerTable checks that the one-query-per-kind bulk
+// methods return the same metadata as the per-table methods.
+func TestBulkMetadataMatchesPerTable(t *testing.T) {
+	ctx := context.Background()
+
+	conn, err := analyzer.Connect(ctx, testConnectionString)
+	if err != nil {
+		t.Fatalf("Failed to connect to test database: %v", err)
+	}
+	defer conn.Close()
+
+	schemaAnalyzer := analyzer.NewSchemaAnalyzer(conn)
+
+	tables, err := schemaAnalyzer.GetTables(ctx, []string{"public"})
+	if err != nil {
+		t.Fatalf("Failed to get tables: %v", err)
+	}
+
+	columns, err := schemaAnalyzer.GetColumnsForTables(ctx, tables)
+	if err != nil {
+		t.Fatalf("Failed to get columns in bulk: %v", err)
+	}
+
+	foreignKeys, err := schemaAnalyzer.GetForeignKeysForTables(ctx, tables)
+	if err != nil {
+		t.Fatalf("Failed to get foreign keys in bulk: %v", err)
+	}
+
+	indexes, err := schemaAnalyzer.GetIndexesForTables(ctx, tables)
+	if err != nil {
+		t.Fatalf("Failed to get indexes in bulk: %v", err)
+	}
+
+	for i := range tables {
+		table := &tables[i]
+		key := table.Schema + "." + table.Name
+
+		tableColumns, err := schemaAnalyzer.GetColumns(ctx, table)
+		if err != nil {
+			t.Fatalf("Failed to get columns for %s: %v", table.Name, err)
+		}
+
+		if len(columns[key]) != len(tableColumns) {
+			t.Errorf("%s: bulk query returned %d columns, per-table query %d", key, len(columns[key]), len(tableColumns))
+		}
+
+		tableForeignKeys, err := schemaAnalyzer.GetForeignKeys(ctx, table)
+		if err != nil {
+			t.Fatalf("Failed to get foreign keys for %s: %v", table.Name, err)
+		}
+
+		if len(foreignKeys[key]) != len(tableForeignKeys) {
+			t.Errorf("%s: bulk query returned %d foreign keys, per-table query %d", key, len(foreignKeys[key]), len(tableForeignKeys))
+		}
+
+		tableIndexes, err := schemaAnalyzer.GetIndexes(ctx, table)
+		if err != nil {
+			t.Fatalf("Failed to get indexes for %s: %v", table.Name, err)
+		}
+
+		if len(indexes[key]) != len(tableIndexes) {
+			t.Errorf("%s: bulk query returned %d indexes, per-table query %d", key, len(indexes[key]), len(tableIndexes))
+		}
+	}
+}
+
+// TestSnapshotExtraction checks that a transaction joining an exported
+// snapshot sees the same data as the exporting one, and that neither sees a
+// table created after the snapshot was taken.
+func TestSnapshotExtraction(t *testing.T) {
+	ctx := context.Background()
+
+	conn, err := analyzer.Connect(ctx, testConnectionString)
+	if err != nil {
+		t.Fatalf("Failed to connect to test database: %v", err)
+	}
+	defer conn.Close()
+
+	leader, snapshot, err := conn.BeginSnapshot(ctx)
+	if err != nil {
+		t.Fatalf("Failed to begin snapshot: %v", err)
+	}
+	defer leader.Close()
+
+	if snapshot.ID == "" || snapshot.TakenAt.IsZero() {
+		t.Fatalf("Expected an exported snapshot ID and time, got %+v", snapshot)
+	}
+
+	worker, err := conn.JoinSnapshot(ctx, snapshot.ID)
+	if err != nil {
+		t.Fatalf("Failed to join snapshot: %v", err)
+	}
+	defer worker.Close()
+
+	if _, err := conn.DB().ExecContext(ctx, "CREATE TABLE snapshot_probe (id integer)"); err != nil {
+		t.Fatalf("Failed to create probe table: %v", err)
+	}
+	defer func() { _, _ = conn.DB().ExecContext(ctx, "DROP TABLE snapshot_probe") }()
+
+	for name, snapshotConn := range map[string]*analyzer.Connection{"leader": leader, "worker": worker} {
+		schemaAnalyzer := analyzer.NewSchemaAnalyzer(snapshotConn)
+
+		tables, err := schemaAnalyzer.GetTables(ctx, []string{"public"})
+		if err != nil {
+			t.Fatalf("%s: failed to get tables: %v", name, err)
+		}
+
+		for _, table := range tables {
+			if table.Name == "snapshot_probe" {
+				t.Errorf("%s: saw a table created after the snapshot", name)
+			}
+		}
+
+		rowCounts, err := schemaAnalyzer.GetTableRowCounts(ctx, tables,
+			analyzer.RowCountOptions{Method: models.RowCountExact, Timeout: time.Second})
+		if err != nil {
+			t.Fatalf("%s: failed to count rows inside the snapshot: %v", name, err)
+		}
+
+		if rowCounts["public.users"].Count != 3 {
+			t.Errorf("%s: expected 3 users, got %d", name, rowCounts["public.users"].Count)
+		}
+	}
+}
+
+// TestSnapshotOutlivesIdleTimeout checks that the exporting transaction is
+// not ended by the safe mode idle timeout while it waits, so that workers can
+// still join its snapshot later.
+func TestSnapshotOutlivesIdleTimeout(t *testing.T) {
+	ctx := context.Background()
+
+	conn, err := analyzer.ConnectSafe(ctx, testConnectionString, analyzer.PostgreSQL, analyzer.SafeMode{IdleTimeout: time.Second})
+	if err != nil {
+		t.Fatalf("Failed to connect in safe mode: %v", err)
+	}
+	defer conn.Close()
+
+	leader, snapshot, err := conn.BeginSnapshot(ctx)
+	if err != nil {
+		t.Fatalf("Failed to begin snapshot: %v", err)
+	}
+	defer leader.Close()
+
+	time.Sleep(3 * time.Second)
+
+	worker, err := conn.JoinSnapshot(ctx, snapshot.ID)
+	if err != nil {
+		t.Fatalf("Failed to join snapshot after the idle timeout: %v", err)
+	}
+	defer worker.Close()
+
+	if _, err := analyzer.NewSchemaAnalyzer(leader).GetTables(ctx, []string{"public"}); err != nil {
+		t.Errorf("Expected the exporting transaction to stay usable, got %v", err)
+	}
+}
+
+// TestSafeModeSessionSettings checks that safe mode settings reach every
+// session, including the snapshot transactions.
+func TestSafeModeSessionSettings(t *testing.T) {
+	ctx := context.Background()
+
+	conn, err := analyzer.ConnectSafe(ctx, testConnectionString, analyzer.PostgreSQL,
+		analyzer.SafeMode{StatementTimeout: 30 * time.Second, LockTimeout: 2 * time.Second, IdleTimeout: time.Minute})
+	if err != nil {
+		t.Fatalf("Failed to connect in safe mode: %v", err)
+	}
+	defer conn.Close()
+
+	expected := map[string]string{
+		"application_name":              "pg-goer",
+		"default_transaction_read_only": "on",
+		"statement_timeout":             "30s",
+		"lock_timeout":                  "2s",
+	}
+
+	for setting, value := range expected {
+		var got string
+		if err := conn.DB().QueryRowContext(ctx, "SELECT current_setting($1)", setting).Scan(&got); err != nil {
+			t.Fatalf("Failed to read %s: %v", setting, err)
+		}
+
+		if got != value {
+			t.Errorf("Expected %s = %s, got %s", setting, value, got)
+		}
+	}
+
+	if _, err := conn.DB().ExecContext(ctx, "CREATE TABLE safe_mode_probe (id integer)"); err == nil {
+		_, _ = conn.DB().ExecContext(ctx, "DROP TABLE safe_mode_probe")
+		t.Error("Expected writes to be rejected in safe mode")
+	}
+}
+
 func TestCompleteWorkflow(t *testing.T) {
 	ctx := context.Background()
 
@@ -275,15 +467,15 @@ func TestCompleteWorkflow(t *testing.T) {
 	}
 
 	// Get row counts
-	rowCounts, err := schemaAnalyzer.GetTableRowCounts(ctx, tables)
+	rowCounts, err := schemaAnalyzer.GetTableRowCounts(ctx, tables, analyzer.RowCountOptions{Method: models.RowCountEstimate})
 	if err != nil {
 		t.Fatalf("Failed to get row counts: %v", err)
 	}
 
 	// Apply row counts to tables
 	for i := range tables {
-		if count, exists := rowCounts[tables[i].Name]; exists {
-			tables[i].RowCount = count
+		if rowCount, exists := rowCounts[tables[i].Schema+"."+tables[i].Name]; exists {
+			tables[i].RowCount = rowCount.Count
 		}
 	}
 